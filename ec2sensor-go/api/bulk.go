@@ -0,0 +1,144 @@
+package api
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/quanticsoul4772/ec2sensor-go/models"
+)
+
+// rateLimiter is a simple token-bucket limiter guarding outbound API calls so
+// bulk fetches don't trip API-Gateway throttling. It starts full so a burst
+// up to ratePerSecond can go out immediately, then refills at that rate.
+type rateLimiter struct {
+	tokens chan struct{}
+	ticker *time.Ticker
+	done   chan struct{}
+}
+
+func newRateLimiter(ratePerSecond int) *rateLimiter {
+	rl := &rateLimiter{
+		tokens: make(chan struct{}, ratePerSecond),
+		ticker: time.NewTicker(time.Second / time.Duration(ratePerSecond)),
+		done:   make(chan struct{}),
+	}
+	for i := 0; i < ratePerSecond; i++ {
+		rl.tokens <- struct{}{}
+	}
+	go rl.refill()
+	return rl
+}
+
+func (rl *rateLimiter) refill() {
+	for {
+		select {
+		case <-rl.ticker.C:
+			select {
+			case rl.tokens <- struct{}{}:
+			default:
+			}
+		case <-rl.done:
+			rl.ticker.Stop()
+			return
+		}
+	}
+}
+
+// wait blocks until a token is available.
+func (rl *rateLimiter) wait() {
+	<-rl.tokens
+}
+
+// FetchResult is a single sensor fetch outcome, tagged with its index in the
+// original names slice so callers can reassemble ordered results from a
+// stream that completes out of order.
+type FetchResult struct {
+	Index  int
+	Name   string
+	Sensor *models.Sensor
+	Err    error
+}
+
+// fetchConcurrently runs fetch for each name through a bounded worker pool
+// gated by limiter, streaming each result on the returned channel as soon as
+// it completes. The channel is closed once every name has been fetched, or
+// once ctx is cancelled (remaining names are reported with ctx.Err()).
+func fetchConcurrently(ctx context.Context, names []string, poolSize int, limiter *rateLimiter, fetch func(ctx context.Context, name string) (*models.Sensor, error)) <-chan FetchResult {
+	out := make(chan FetchResult, len(names))
+	if len(names) == 0 {
+		close(out)
+		return out
+	}
+
+	if poolSize <= 0 {
+		poolSize = defaultPoolSize
+	}
+	if poolSize > len(names) {
+		poolSize = len(names)
+	}
+
+	type job struct {
+		index int
+		name  string
+	}
+	jobs := make(chan job)
+	var wg sync.WaitGroup
+
+	for w := 0; w < poolSize; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				limiter.wait()
+				sensor, err := fetch(ctx, j.name)
+				out <- FetchResult{Index: j.index, Name: j.name, Sensor: sensor, Err: err}
+			}
+		}()
+	}
+
+	go func() {
+		for i, name := range names {
+			select {
+			case jobs <- job{index: i, name: name}:
+			case <-ctx.Done():
+				for j := i; j < len(names); j++ {
+					out <- FetchResult{Index: j, Name: names[j], Err: ctx.Err()}
+				}
+				close(jobs)
+				wg.Wait()
+				close(out)
+				return
+			}
+		}
+		close(jobs)
+		wg.Wait()
+		close(out)
+	}()
+
+	return out
+}
+
+// FetchSensorsStream fetches names concurrently through a bounded worker
+// pool (Client.poolSize, rate-limited by Client.limiter), delivering each
+// result on the returned channel as soon as it completes rather than
+// waiting for the slowest sensor. Results arrive in completion order, not
+// input order; use FetchResult.Index to place them. Cancelling ctx stops
+// dispatching further fetches and reports ctx.Err() for the remainder.
+func (c *Client) FetchSensorsStream(ctx context.Context, names []string) <-chan FetchResult {
+	return fetchConcurrently(ctx, names, c.poolSize, c.limiter, c.FetchSensor)
+}
+
+// FetchSensors fetches names concurrently via FetchSensorsStream and
+// collects the results into a slice ordered to match names, for callers
+// that don't need incremental results. A failed fetch leaves a nil sensor
+// and its error at that index; other sensors are unaffected.
+func (c *Client) FetchSensors(ctx context.Context, names []string) ([]*models.Sensor, []error) {
+	sensors := make([]*models.Sensor, len(names))
+	errs := make([]error, len(names))
+	for res := range c.FetchSensorsStream(ctx, names) {
+		sensors[res.Index] = res.Sensor
+		errs[res.Index] = res.Err
+	}
+	return sensors, errs
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/quanticsoul4772/ec2sensor-go/models"
+	"github.com/quanticsoul4772/ec2sensor-go/store"
+)
+
+// CachingClient wraps a Client with a store.SensorStore: FetchSensor results
+// within ttl are served from the store instead of re-hitting the API, and
+// CreateSensor/DeleteSensor write through on success so other clients
+// sharing the same backend see the change via store.Watch. A zero ttl
+// disables the freshness window, always re-fetching from the API.
+type CachingClient struct {
+	*Client
+	store store.SensorStore
+	ttl   time.Duration
+
+	mu        sync.Mutex
+	fetchedAt map[string]time.Time
+}
+
+// NewCachingClient wraps client with st, caching FetchSensor responses for ttl.
+func NewCachingClient(client *Client, st store.SensorStore, ttl time.Duration) *CachingClient {
+	return &CachingClient{
+		Client:    client,
+		store:     st,
+		ttl:       ttl,
+		fetchedAt: make(map[string]time.Time),
+	}
+}
+
+// FetchSensor returns the cached sensor if it was fetched within ttl,
+// otherwise fetches from the API and writes the result through to the store.
+func (c *CachingClient) FetchSensor(ctx context.Context, sensorName string) (*models.Sensor, error) {
+	if c.ttl > 0 {
+		c.mu.Lock()
+		fetchedAt, fresh := c.fetchedAt[sensorName]
+		c.mu.Unlock()
+		if fresh && time.Since(fetchedAt) < c.ttl {
+			if cached, err := c.store.Get(sensorName); err == nil {
+				return cached, nil
+			}
+		}
+	}
+
+	sensor, err := c.Client.FetchSensor(ctx, sensorName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.store.Put(sensor); err == nil {
+		c.mu.Lock()
+		c.fetchedAt[sensorName] = time.Now()
+		c.mu.Unlock()
+	}
+
+	return sensor, nil
+}
+
+// FetchSensorsStream is FetchSensor fetched concurrently through the
+// embedded Client's worker pool, so cache hits and write-through still
+// apply per sensor.
+func (c *CachingClient) FetchSensorsStream(ctx context.Context, names []string) <-chan FetchResult {
+	return fetchConcurrently(ctx, names, c.Client.poolSize, c.Client.limiter, c.FetchSensor)
+}
+
+// CreateSensor creates a sensor via the API and writes its initial state
+// through to the store so Watch subscribers see it immediately.
+func (c *CachingClient) CreateSensor(ctx context.Context) (string, error) {
+	return c.CreateSensorWithOptions(ctx, CreateSensorOptions{})
+}
+
+// CreateSensorWithOptions creates a sensor via the API using opts and
+// writes its initial state through to the store so Watch subscribers see
+// it immediately.
+func (c *CachingClient) CreateSensorWithOptions(ctx context.Context, opts CreateSensorOptions) (string, error) {
+	name, err := c.Client.CreateSensorWithOptions(ctx, opts)
+	if err != nil {
+		return "", err
+	}
+	c.store.Put(&models.Sensor{Name: name, Status: models.StatusPending})
+	return name, nil
+}
+
+// DeleteSensor deletes a sensor via the API and removes it from the store so
+// other clients sharing the backend stop seeing it.
+func (c *CachingClient) DeleteSensor(ctx context.Context, sensorName string) error {
+	if err := c.Client.DeleteSensor(ctx, sensorName); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	delete(c.fetchedAt, sensorName)
+	c.mu.Unlock()
+	return c.store.Delete(sensorName)
+}
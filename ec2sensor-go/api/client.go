@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -9,64 +10,159 @@ import (
 	"time"
 
 	"github.com/quanticsoul4772/ec2sensor-go/config"
+	"github.com/quanticsoul4772/ec2sensor-go/metrics"
 	"github.com/quanticsoul4772/ec2sensor-go/models"
 )
 
+// SensorAPI is the surface the rest of the app depends on. Client implements
+// it directly; CachingClient wraps a Client with a store.SensorStore for
+// shared caching and persistence.
+type SensorAPI interface {
+	FetchSensor(ctx context.Context, sensorName string) (*models.Sensor, error)
+	FetchSensorsStream(ctx context.Context, names []string) <-chan FetchResult
+	DeleteSensor(ctx context.Context, sensorName string) error
+	CreateSensor(ctx context.Context) (string, error)
+}
+
 // Client handles API communication with the EC2 sensor service
 type Client struct {
-	baseURL    string
-	apiKey     string
-	httpClient *http.Client
-	maxRetries int
+	baseURL     string
+	apiKey      string
+	httpClient  *http.Client
+	retryPolicy RetryPolicy
+
+	// poolSize bounds concurrent FetchSensor calls made by
+	// FetchSensorsStream; limiter throttles the rate of outbound requests
+	// across all of them.
+	poolSize int
+	limiter  *rateLimiter
+
+	// metrics is nil unless SetMetrics is called, in which case every
+	// request is recorded against it.
+	metrics *metrics.Metrics
+
+	// logger defaults to nopLogger; SetLogger attaches a real sink.
+	logger Logger
+}
+
+// SetMetrics attaches m so every FetchSensor/CreateSensor/DeleteSensor call
+// records its count, retries and latency. Passing nil disables recording.
+func (c *Client) SetMetrics(m *metrics.Metrics) {
+	c.metrics = m
 }
 
-// NewClient creates a new API client
+// defaultPoolSize and defaultRateLimit are used when cfg leaves the
+// corresponding field at zero.
+const (
+	defaultPoolSize  = 8
+	defaultRateLimit = 10 // requests per second
+)
+
+// NewClient creates a new API client using DefaultRetryPolicy.
 func NewClient(cfg *config.Config) *Client {
+	return NewClientWithOptions(cfg, DefaultRetryPolicy())
+}
+
+// NewClientWithOptions creates a new API client with a custom RetryPolicy.
+func NewClientWithOptions(cfg *config.Config, policy RetryPolicy) *Client {
+	poolSize := cfg.APIPoolSize
+	if poolSize <= 0 {
+		poolSize = defaultPoolSize
+	}
+	rateLimit := cfg.APIRateLimit
+	if rateLimit <= 0 {
+		rateLimit = defaultRateLimit
+	}
+
 	return &Client{
 		baseURL: cfg.APIBaseURL,
 		apiKey:  cfg.APIKey,
 		httpClient: &http.Client{
 			Timeout: 10 * time.Second,
 		},
-		maxRetries: 3,
+		retryPolicy: policy,
+		poolSize:    poolSize,
+		limiter:     newRateLimiter(rateLimit),
+		logger:      nopLogger{},
+	}
+}
+
+// recordRequest reports operation's outcome to c.metrics, if one is
+// attached via SetMetrics; it is a no-op otherwise.
+func (c *Client) recordRequest(operation string, err error, retries int, start time.Time) {
+	if c.metrics == nil {
+		return
+	}
+	c.metrics.ObserveRequest(operation, err, retries, time.Since(start))
+}
+
+// waitToRetry sleeps for delay, honoring ctx cancellation.
+func waitToRetry(ctx context.Context, delay time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(delay):
+		return nil
 	}
 }
 
 // FetchSensor retrieves sensor data from the API
-func (c *Client) FetchSensor(sensorName string) (*models.Sensor, error) {
+func (c *Client) FetchSensor(ctx context.Context, sensorName string) (*models.Sensor, error) {
+	start := time.Now()
+	reqID := nextRequestID()
 	url := fmt.Sprintf("%s/%s", c.baseURL, sensorName)
 
 	var lastErr error
-	for attempt := 0; attempt < c.maxRetries; attempt++ {
-		if attempt > 0 {
-			time.Sleep(time.Duration(attempt) * time.Second) // Exponential backoff
-		}
+	var lastStatus int
+	attempt := 0
+	for {
+		attempt++
 
-		req, err := http.NewRequest("GET", url, nil)
+		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 		if err != nil {
 			lastErr = err
-			continue
+			break
 		}
 
 		req.Header.Set("x-api-key", c.apiKey)
+		c.logger.Debugf("fetch request sensor=%s attempt=%d request_id=%s url=%s headers=%v", sensorName, attempt, reqID, url, redactedHeaders(req.Header))
 
 		resp, err := c.httpClient.Do(req)
 		if err != nil {
 			lastErr = err
+			lastStatus = 0
+			if !c.retryPolicy.shouldRetry(attempt, err, lastStatus, time.Since(start)) {
+				break
+			}
+			if werr := c.waitAndLogRetry(ctx, "fetch", sensorName, reqID, attempt, lastErr, lastStatus, start); werr != nil {
+				c.recordRequest("fetch", werr, attempt, start)
+				return nil, werr
+			}
 			continue
 		}
 
-		body, err := io.ReadAll(resp.Body)
+		body, readErr := io.ReadAll(resp.Body)
+		lastStatus = resp.StatusCode
 		resp.Body.Close() // Close immediately, not deferred in loop
-		if err != nil {
-			lastErr = err
+		if readErr != nil {
+			lastErr = readErr
+			if !c.retryPolicy.shouldRetry(attempt, lastErr, lastStatus, time.Since(start)) {
+				break
+			}
+			if werr := c.waitAndLogRetry(ctx, "fetch", sensorName, reqID, attempt, lastErr, lastStatus, start); werr != nil {
+				c.recordRequest("fetch", werr, attempt, start)
+				return nil, werr
+			}
 			continue
 		}
 
+		c.logger.Debugf("fetch response sensor=%s attempt=%d request_id=%s status=%d duration=%s body=%s", sensorName, attempt, reqID, lastStatus, time.Since(start), body)
+
 		// Check for error response (plain text)
 		bodyStr := string(body)
 		if strings.Contains(bodyStr, "Error:") && strings.Contains(bodyStr, "does not exist") {
 			// Sensor doesn't exist in API - mark as deleted so it gets cleaned up
+			c.recordRequest("fetch", nil, attempt-1, start)
 			return &models.Sensor{
 				Name:    sensorName,
 				IP:      "",
@@ -79,6 +175,13 @@ func (c *Client) FetchSensor(sensorName string) (*models.Sensor, error) {
 		var sensor models.Sensor
 		if err := json.Unmarshal(body, &sensor); err != nil {
 			lastErr = fmt.Errorf("failed to parse response: %w", err)
+			if !c.retryPolicy.shouldRetry(attempt, lastErr, lastStatus, time.Since(start)) {
+				break
+			}
+			if werr := c.waitAndLogRetry(ctx, "fetch", sensorName, reqID, attempt, lastErr, lastStatus, start); werr != nil {
+				c.recordRequest("fetch", werr, attempt, start)
+				return nil, werr
+			}
 			continue
 		}
 
@@ -93,42 +196,74 @@ func (c *Client) FetchSensor(sensorName string) (*models.Sensor, error) {
 			sensor.Status = models.StatusDeleted
 		}
 
+		c.recordRequest("fetch", nil, attempt-1, start)
 		return &sensor, nil
 	}
 
-	return nil, fmt.Errorf("API request failed after %d attempts: %v", c.maxRetries, lastErr)
+	err := fmt.Errorf("API request failed after %d attempts: %w", attempt, lastErr)
+	c.recordRequest("fetch", err, attempt-1, start)
+	return nil, err
+}
+
+// waitAndLogRetry logs a warn-level retry message for operation, then sleeps
+// for the policy's next delay, honoring ctx cancellation.
+func (c *Client) waitAndLogRetry(ctx context.Context, operation, sensorName, reqID string, attempt int, err error, status int, start time.Time) error {
+	delay := c.retryPolicy.delay(attempt)
+	c.logger.Warnf("%s retry sensor=%s attempt=%d request_id=%s last_err=%v last_status=%d delay=%s", operation, sensorName, attempt, reqID, err, status, delay)
+	return waitToRetry(ctx, delay)
 }
 
 // DeleteSensor deletes a sensor via the API
-func (c *Client) DeleteSensor(sensorName string) error {
+func (c *Client) DeleteSensor(ctx context.Context, sensorName string) error {
+	start := time.Now()
+	reqID := nextRequestID()
 	url := fmt.Sprintf("%s/delete/%s", c.baseURL, sensorName)
 
-	req, err := http.NewRequest("DELETE", url, nil)
-	if err != nil {
-		return err
-	}
+	var lastErr error
+	var lastStatus int
+	attempt := 0
+	for {
+		attempt++
 
-	req.Header.Set("x-api-key", c.apiKey)
+		req, err := http.NewRequestWithContext(ctx, "DELETE", url, nil)
+		if err != nil {
+			c.recordRequest("delete", err, attempt-1, start)
+			return err
+		}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
+		req.Header.Set("x-api-key", c.apiKey)
+		c.logger.Debugf("delete request sensor=%s attempt=%d request_id=%s url=%s headers=%v", sensorName, attempt, reqID, url, redactedHeaders(req.Header))
 
-	if resp.StatusCode >= 400 {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("delete failed with status %d: %s", resp.StatusCode, string(body))
-	}
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			lastErr = err
+			lastStatus = 0
+		} else {
+			lastStatus = resp.StatusCode
+			if resp.StatusCode >= 400 {
+				body, _ := io.ReadAll(resp.Body)
+				resp.Body.Close() // Close immediately, not deferred in loop
+				lastErr = fmt.Errorf("delete failed with status %d: %s", resp.StatusCode, string(body))
+			} else {
+				resp.Body.Close() // Close immediately, not deferred in loop
+				c.logger.Infof("sensor deleted sensor=%s request_id=%s status=%d duration=%s", sensorName, reqID, resp.StatusCode, time.Since(start))
+				c.recordRequest("delete", nil, attempt-1, start)
+				return nil
+			}
+		}
 
-	return nil
-}
+		if !c.retryPolicy.shouldRetry(attempt, lastErr, lastStatus, time.Since(start)) {
+			break
+		}
+		if werr := c.waitAndLogRetry(ctx, "delete", sensorName, reqID, attempt, lastErr, lastStatus, start); werr != nil {
+			c.recordRequest("delete", werr, attempt, start)
+			return werr
+		}
+	}
 
-// CreateSensorRequest holds the payload for creating a new sensor
-type CreateSensorRequest struct {
-	DevelopmentBranch string `json:"development_branch"`
-	TeamName          string `json:"team_name"`
-	Username          string `json:"username"`
+	c.logger.Errorf("delete failed sensor=%s request_id=%s attempts=%d duration=%s err=%v", sensorName, reqID, attempt, time.Since(start), lastErr)
+	c.recordRequest("delete", lastErr, attempt-1, start)
+	return lastErr
 }
 
 // CreateSensorResponse holds the response from creating a sensor
@@ -138,65 +273,155 @@ type CreateSensorResponse struct {
 	SensorStatus  string `json:"sensor_status"`
 }
 
-// CreateSensor creates a new sensor via the API
-func (c *Client) CreateSensor() (string, error) {
-	url := fmt.Sprintf("%s/create", c.baseURL)
+// CreateSensorOptions configures CreateSensorWithOptions. Zero-valued
+// fields fall back to CreateSensor's original hardcoded defaults.
+type CreateSensorOptions struct {
+	DevelopmentBranch string
+	TeamName          string
+	Username          string
+	// Extra carries additional fields to include in the create request
+	// payload, for API parameters this client doesn't model directly.
+	Extra map[string]string
+}
 
-	// Default values matching the bash script
-	payload := CreateSensorRequest{
-		DevelopmentBranch: "testing",
-		TeamName:          "cicd",
-		Username:          "codebuff", // Default username
+// withDefaults fills unset fields with CreateSensor's original values.
+func (o CreateSensorOptions) withDefaults() CreateSensorOptions {
+	if o.DevelopmentBranch == "" {
+		o.DevelopmentBranch = "testing"
 	}
-
-	jsonPayload, err := json.Marshal(payload)
-	if err != nil {
-		return "", fmt.Errorf("failed to marshal request: %w", err)
+	if o.TeamName == "" {
+		o.TeamName = "cicd"
 	}
+	if o.Username == "" {
+		o.Username = "codebuff"
+	}
+	return o
+}
 
-	req, err := http.NewRequest("POST", url, strings.NewReader(string(jsonPayload)))
-	if err != nil {
-		return "", err
+// payload renders o as the JSON body CreateSensor's endpoint expects, with
+// Extra fields merged in alongside the named ones.
+func (o CreateSensorOptions) payload() ([]byte, error) {
+	o = o.withDefaults()
+	fields := map[string]interface{}{
+		"development_branch": o.DevelopmentBranch,
+		"team_name":          o.TeamName,
+		"username":           o.Username,
+	}
+	for k, v := range o.Extra {
+		fields[k] = v
 	}
+	return json.Marshal(fields)
+}
 
-	req.Header.Set("accept", "application/json")
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("x-api-key", c.apiKey)
+// CreateSensor creates a new sensor via the API using the original default
+// payload (branch "testing", team "cicd", username "codebuff").
+func (c *Client) CreateSensor(ctx context.Context) (string, error) {
+	return c.CreateSensorWithOptions(ctx, CreateSensorOptions{})
+}
 
-	// Use a longer timeout for creation
-	client := &http.Client{Timeout: 30 * time.Second}
-	resp, err := client.Do(req)
+// CreateSensorWithOptions creates a new sensor via the API, using opts to
+// fill in (or override) the request payload. Unlike the original
+// implementation, failures are retried under the same RetryPolicy as
+// FetchSensor/DeleteSensor, skipping retries on terminal 4xx responses.
+func (c *Client) CreateSensorWithOptions(ctx context.Context, opts CreateSensorOptions) (string, error) {
+	start := time.Now()
+	reqID := nextRequestID()
+	url := fmt.Sprintf("%s/create", c.baseURL)
+
+	jsonPayload, err := opts.payload()
 	if err != nil {
+		err = fmt.Errorf("failed to marshal request: %w", err)
+		c.recordRequest("create", err, 0, start)
 		return "", err
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", fmt.Errorf("failed to read response: %w", err)
-	}
+	var lastErr error
+	var lastStatus int
+	attempt := 0
+	for {
+		attempt++
 
-	if resp.StatusCode >= 400 {
-		return "", fmt.Errorf("create failed with status %d: %s", resp.StatusCode, string(body))
-	}
+		req, err := http.NewRequestWithContext(ctx, "POST", url, strings.NewReader(string(jsonPayload)))
+		if err != nil {
+			c.recordRequest("create", err, attempt-1, start)
+			return "", err
+		}
 
-	var createResp CreateSensorResponse
-	if err := json.Unmarshal(body, &createResp); err != nil {
-		return "", fmt.Errorf("failed to parse response: %w", err)
-	}
+		req.Header.Set("accept", "application/json")
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("x-api-key", c.apiKey)
+		c.logger.Debugf("create request attempt=%d request_id=%s url=%s headers=%v body=%s", attempt, reqID, url, redactedHeaders(req.Header), jsonPayload)
+
+		resp, doErr := c.httpClient.Do(req)
+		if doErr != nil {
+			lastErr = doErr
+			lastStatus = 0
+			if !c.retryPolicy.shouldRetry(attempt, lastErr, lastStatus, time.Since(start)) {
+				break
+			}
+			if werr := c.waitAndLogRetry(ctx, "create", "", reqID, attempt, lastErr, lastStatus, start); werr != nil {
+				c.recordRequest("create", werr, attempt, start)
+				return "", werr
+			}
+			continue
+		}
+		lastStatus = resp.StatusCode
+
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close() // Close immediately, not deferred in loop
+		if err != nil {
+			lastErr = fmt.Errorf("failed to read response: %w", err)
+			if !c.retryPolicy.shouldRetry(attempt, lastErr, lastStatus, time.Since(start)) {
+				break
+			}
+			if werr := c.waitAndLogRetry(ctx, "create", "", reqID, attempt, lastErr, lastStatus, start); werr != nil {
+				c.recordRequest("create", werr, attempt, start)
+				return "", werr
+			}
+			continue
+		}
+
+		c.logger.Debugf("create response attempt=%d request_id=%s status=%d duration=%s body=%s", attempt, reqID, resp.StatusCode, time.Since(start), body)
+
+		if resp.StatusCode >= 400 {
+			lastErr = fmt.Errorf("create failed with status %d: %s", resp.StatusCode, string(body))
+			if !c.retryPolicy.shouldRetry(attempt, lastErr, lastStatus, time.Since(start)) {
+				break
+			}
+			if werr := c.waitAndLogRetry(ctx, "create", "", reqID, attempt, lastErr, lastStatus, start); werr != nil {
+				c.recordRequest("create", werr, attempt, start)
+				return "", werr
+			}
+			continue
+		}
+
+		var createResp CreateSensorResponse
+		if err := json.Unmarshal(body, &createResp); err != nil {
+			err = fmt.Errorf("failed to parse response: %w", err)
+			c.recordRequest("create", err, attempt-1, start)
+			return "", err
+		}
+
+		if createResp.EC2SensorName == "" {
+			err := fmt.Errorf("no sensor name in response")
+			c.recordRequest("create", err, attempt-1, start)
+			return "", err
+		}
 
-	if createResp.EC2SensorName == "" {
-		return "", fmt.Errorf("no sensor name in response")
+		c.logger.Infof("sensor created sensor=%s request_id=%s status=%d duration=%s", createResp.EC2SensorName, reqID, resp.StatusCode, time.Since(start))
+		c.recordRequest("create", nil, attempt-1, start)
+		return createResp.EC2SensorName, nil
 	}
 
-	return createResp.EC2SensorName, nil
+	c.recordRequest("create", lastErr, attempt-1, start)
+	return "", fmt.Errorf("create sensor failed after %d attempts: %w", attempt, lastErr)
 }
 
 // TestConnectivity checks if the API is reachable
-func (c *Client) TestConnectivity() bool {
+func (c *Client) TestConnectivity(ctx context.Context) bool {
 	url := fmt.Sprintf("%s/test-connectivity-check", c.baseURL)
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return false
 	}
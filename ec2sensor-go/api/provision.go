@@ -0,0 +1,91 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/quanticsoul4772/ec2sensor-go/models"
+)
+
+// createPollInterval and createPollTimeout bound how long CreateSensors
+// waits for a freshly created sensor to reach StatusRunning.
+const (
+	createPollInterval = 5 * time.Second
+	createPollTimeout  = 5 * time.Minute
+)
+
+// CreateSensors provisions len(opts) sensors concurrently (bounded by
+// c.poolSize), then polls each via FetchSensor until it reaches
+// StatusRunning or createPollTimeout elapses. Results are returned in the
+// same order as opts; a failed create or a sensor that never becomes ready
+// leaves a nil entry and its error at that index. Cancelling ctx stops
+// polling early and reports ctx.Err() for any sensor still pending.
+func (c *Client) CreateSensors(ctx context.Context, opts []CreateSensorOptions) ([]*models.Sensor, []error) {
+	sensors := make([]*models.Sensor, len(opts))
+	errs := make([]error, len(opts))
+
+	poolSize := c.poolSize
+	if poolSize <= 0 || poolSize > len(opts) {
+		poolSize = len(opts)
+	}
+	if poolSize == 0 {
+		return sensors, errs
+	}
+
+	type job struct {
+		index int
+		opts  CreateSensorOptions
+	}
+	jobs := make(chan job)
+	var wg sync.WaitGroup
+
+	for w := 0; w < poolSize; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				name, err := c.CreateSensorWithOptions(ctx, j.opts)
+				if err != nil {
+					errs[j.index] = err
+					continue
+				}
+				sensors[j.index], errs[j.index] = c.waitUntilRunning(ctx, name)
+			}
+		}()
+	}
+
+	for i, o := range opts {
+		jobs <- job{index: i, opts: o}
+	}
+	close(jobs)
+	wg.Wait()
+
+	return sensors, errs
+}
+
+// waitUntilRunning polls FetchSensor for name until it reaches
+// StatusRunning, ctx is cancelled, or createPollTimeout elapses.
+func (c *Client) waitUntilRunning(ctx context.Context, name string) (*models.Sensor, error) {
+	deadline := time.Now().Add(createPollTimeout)
+	ticker := time.NewTicker(createPollInterval)
+	defer ticker.Stop()
+
+	for {
+		sensor, err := c.FetchSensor(ctx, name)
+		if err == nil && sensor.Status == models.StatusRunning {
+			return sensor, nil
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("sensor %s did not reach running within %s", name, createPollTimeout)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy controls how Client retries a failed request. Delay grows
+// from BaseDelay by Multiplier on each attempt, capped at MaxDelay, with up
+// to +/-Jitter fractional randomness layered on top. Retrying stops once
+// MaxRetries attempts have been made or MaxElapsedTime has passed since the
+// first attempt, whichever comes first (MaxElapsedTime <= 0 disables that
+// check). RetryableStatus lists extra HTTP status codes worth retrying
+// beyond the always-retried 5xx range; 429 is in the default policy since
+// the API fronts an API Gateway that throttles.
+type RetryPolicy struct {
+	MaxRetries      int
+	BaseDelay       time.Duration
+	MaxDelay        time.Duration
+	Multiplier      float64
+	Jitter          float64
+	MaxElapsedTime  time.Duration
+	RetryableStatus map[int]bool
+}
+
+// DefaultRetryPolicy is used by NewClient. It keeps the original 3-attempt
+// ceiling but grows delay exponentially from 1s instead of linearly, and
+// additionally retries 429 responses.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:     3,
+		BaseDelay:      1 * time.Second,
+		MaxDelay:       30 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+		MaxElapsedTime: 2 * time.Minute,
+		RetryableStatus: map[int]bool{
+			http.StatusTooManyRequests: true,
+		},
+	}
+}
+
+// delay returns how long to wait before the given attempt (1-indexed: the
+// delay before retrying after attempt 1 failed is delay(1)).
+func (p RetryPolicy) delay(attempt int) time.Duration {
+	d := float64(p.BaseDelay) * math.Pow(p.Multiplier, float64(attempt-1))
+	if p.MaxDelay > 0 && d > float64(p.MaxDelay) {
+		d = float64(p.MaxDelay)
+	}
+	if p.Jitter > 0 {
+		d *= 1 + p.Jitter*(rand.Float64()*2-1)
+	}
+	if d < 0 {
+		d = 0
+	}
+	return time.Duration(d)
+}
+
+// retryableStatus reports whether status is worth retrying: all 5xx codes,
+// plus anything in RetryableStatus (e.g. 429). 4xx codes other than those
+// listed are treated as terminal validation failures.
+func (p RetryPolicy) retryableStatus(status int) bool {
+	if status >= 500 {
+		return true
+	}
+	return p.RetryableStatus[status]
+}
+
+// shouldRetry reports whether attempt (1-indexed, the attempt that just
+// finished) should be retried given its outcome: err is any transport/parse
+// error from that attempt, status is its HTTP status code (0 if no response
+// was received), and elapsed is the time since the first attempt. Network
+// and parse errors are always worth retrying; terminal 4xx responses (other
+// than those in RetryableStatus) are not.
+func (p RetryPolicy) shouldRetry(attempt int, err error, status int, elapsed time.Duration) bool {
+	if attempt >= p.MaxRetries {
+		return false
+	}
+	if p.MaxElapsedTime > 0 && elapsed >= p.MaxElapsedTime {
+		return false
+	}
+	if status >= 400 {
+		return p.retryableStatus(status)
+	}
+	if err != nil {
+		return true
+	}
+	return p.retryableStatus(status)
+}
@@ -0,0 +1,126 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/quanticsoul4772/ec2sensor-go/models"
+)
+
+// EventKind identifies what changed about a sensor between two Watcher
+// polls.
+type EventKind int
+
+const (
+	SensorAdded EventKind = iota
+	SensorStatusChanged
+	SensorDeleted
+)
+
+// Event reports one sensor's change, diffed between successive polls.
+// Sensor is nil for SensorDeleted.
+type Event struct {
+	Kind   EventKind
+	Name   string
+	Sensor *models.Sensor
+}
+
+// Watcher polls a changing set of sensor names at an interval and diffs
+// each snapshot against the last by name and a status hash, so callers can
+// apply incremental updates instead of replacing their whole view of the
+// fleet on every poll. names is called fresh before every poll so sensors
+// added to or removed from the underlying .sensors file are picked up
+// without restarting the Watcher.
+type Watcher struct {
+	client   SensorAPI
+	names    func() []string
+	interval time.Duration
+}
+
+// NewWatcher builds a Watcher polling client every interval for the sensor
+// names returned by names.
+func NewWatcher(client SensorAPI, names func() []string, interval time.Duration) *Watcher {
+	return &Watcher{client: client, names: names, interval: interval}
+}
+
+// Run starts polling in a background goroutine and returns a channel of
+// Events. The channel is closed once ctx is cancelled.
+func (w *Watcher) Run(ctx context.Context) <-chan Event {
+	events := make(chan Event)
+
+	go func() {
+		defer close(events)
+
+		previous := map[string]string{} // name -> status hash
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			names := w.names()
+			listed := make(map[string]struct{}, len(names))
+			for _, name := range names {
+				listed[name] = struct{}{}
+			}
+
+			current := map[string]*models.Sensor{}
+			for res := range w.client.FetchSensorsStream(ctx, names) {
+				if res.Err == nil && res.Sensor != nil {
+					current[res.Name] = res.Sensor
+				}
+			}
+
+			for name, sensor := range current {
+				hash := statusHash(sensor)
+				prevHash, existed := previous[name]
+				var ev Event
+				switch {
+				case !existed:
+					ev = Event{Kind: SensorAdded, Name: name, Sensor: sensor}
+				case prevHash != hash:
+					ev = Event{Kind: SensorStatusChanged, Name: name, Sensor: sensor}
+				default:
+					continue
+				}
+				previous[name] = hash
+				select {
+				case events <- ev:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			// A name drops out of current on a transient fetch error too,
+			// not just a real deletion - genuine absence already arrives
+			// as a synthetic deleted-status sensor (see FetchSensor), so
+			// only declare SensorDeleted once names() itself stops
+			// listing the sensor, rather than whenever this poll's fetch
+			// for it happened to fail.
+			for name := range previous {
+				if _, ok := listed[name]; ok {
+					continue
+				}
+				delete(previous, name)
+				select {
+				case events <- Event{Kind: SensorDeleted, Name: name}:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events
+}
+
+// statusHash summarizes the fields a caller cares about changing; two
+// fetches of the same sensor with equal hashes are treated as unchanged.
+func statusHash(s *models.Sensor) string {
+	return fmt.Sprintf("%s|%s|%s", s.Status, s.BrolinVersion, s.IP)
+}
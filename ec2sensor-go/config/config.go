@@ -4,7 +4,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/BurntSushi/toml"
 	"github.com/joho/godotenv"
 )
 
@@ -13,47 +17,856 @@ type Config struct {
 	// API Configuration
 	APIBaseURL string
 	APIKey     string
+	// APIPoolSize bounds concurrent FetchSensor calls made by
+	// Client.FetchSensorsStream; 0 falls back to the built-in default.
+	APIPoolSize int
+	// APIRateLimit caps outbound API requests per second across the whole
+	// client (including bulk fetches); 0 falls back to the built-in default.
+	APIRateLimit int
+
+	// MaxParallelOps bounds how many sensors a batch operation (upgrade,
+	// delete, ...) runs against at once when fanned out across a
+	// multi-select; 0 falls back to the built-in default.
+	MaxParallelOps int
+	// BatchRetryTimeout bounds how long a batch operation keeps retrying a
+	// single failing sensor (internal/batch.RetryPolicy.MaxElapsedTime)
+	// before giving up on it and moving on; 0 falls back to the built-in
+	// default.
+	BatchRetryTimeout time.Duration
 
 	// SSH Configuration
 	SSHUsername string
 	SSHPassword string
 	SSHUseKeys  bool
+	// SSHInsecureHostKey skips known_hosts verification (ssh.InsecureIgnoreHostKey)
+	// instead of checking against SSHKnownHostsFile. Intended for throwaway
+	// sensors whose host key isn't known ahead of time.
+	SSHInsecureHostKey bool
+	// SSHKnownHostsFile is consulted for host key verification unless
+	// SSHInsecureHostKey is set; defaults to ~/.ssh/known_hosts.
+	SSHKnownHostsFile string
 
 	// UI Configuration
 	Theme string
+	// ThemeExplicit is true when the theme was set by config.toml,
+	// EC2SENSOR_THEME or --theme, so
+	// capability-based auto-detection should defer to it instead of
+	// overriding with the detected terminal theme.
+	ThemeExplicit bool
+	StylesetPath  string
+	BannerFile    string
+	// DashboardRefreshInterval controls how often ViewHealth and the
+	// in-flight operation views (deploy/upgrade/enable-features/
+	// add-to-fleet) re-poll and redraw while they're the active view.
+	DashboardRefreshInterval time.Duration
 
 	// File paths
 	SensorsFile     string
 	OfflineCacheDir string
+	CacheTTL        time.Duration
+
+	// Store backend configuration. Backend selects the store.SensorStore
+	// implementation: "memory" (default), "bolt", or "redis".
+	StoreBackend     string
+	StorePath        string // BoltDB file path, used when Backend == "bolt"
+	RedisAddr        string
+	RedisPassword    string
+	RedisDB          int
+	RedisMaxIdle     int
+	RedisMaxActive   int
+	RedisIdleTimeout time.Duration
+	RedisDialTimeout time.Duration
+
+	// Metrics configuration. When MetricsEnabled, an embedded HTTP server
+	// exposes fleet and API-client metrics in Prometheus format at
+	// MetricsAddr + "/metrics".
+	MetricsEnabled bool
+	MetricsAddr    string
+	// PprofEnabled mounts net/http/pprof's handlers under /debug/pprof/ on
+	// the same listener as MetricsAddr, for live profiling of the collector
+	// process. Has no effect unless MetricsEnabled is also set.
+	PprofEnabled bool
+
+	// Telemetry configuration. When TelemetryEnabled, the telemetry
+	// collector periodically POSTs a fleet report to TelemetryCollectorURL.
+	TelemetryEnabled      bool
+	TelemetryInterval     time.Duration
+	TelemetryCollectorURL string
+	TelemetryBearerToken  string
+	TelemetryGzip         bool
+	// TelemetrySpoolDir holds reports that couldn't be delivered, for later
+	// replay once the collector is reachable again.
+	TelemetrySpoolDir string
+	// TelemetryRedactFields lists report field names (case-insensitive,
+	// matching telemetry.SensorReport's JSON tags) to omit from outgoing
+	// reports, e.g. "ip,services". AdminPassword is never collected in the
+	// first place, regardless of this setting.
+	TelemetryRedactFields string
+
+	// Audit configuration. AuditSinkKind selects where internal/audit's
+	// structured operation events are durably written: "file" (rotating,
+	// the default), "jsonl" (single non-rotating file), "syslog", or
+	// "webhook"; "none" disables the sink (events still populate the
+	// in-memory tail shown in progress views, just aren't persisted).
+	AuditSinkKind     string
+	AuditPath         string
+	AuditMaxSizeBytes int64
+	AuditMaxAge       time.Duration
+	AuditMaxBackups   int
+	AuditWebhookURL   string
+	AuditWebhookToken string
+
+	// ShutdownTimeout bounds how long the shutdown coordinator waits for
+	// StopTrafficGeneration to finish on every tracked host after a
+	// SIGTERM/SIGINT/SIGHUP before the process exits anyway.
+	ShutdownTimeout time.Duration
+
+	// StatsD configuration. When StatsdEnabled, collected sensor metrics are
+	// pushed as gauges to a StatsD/DogStatsD agent at StatsdHost:StatsdPort,
+	// alongside the pull-mode Prometheus endpoint.
+	StatsdEnabled bool
+	StatsdHost    string
+	StatsdPort    int
+
+	// Keys maps a named action (e.g. "refresh", "quit") to the key bound to
+	// it, read from the [keys] section and consumed by MainShortcuts /
+	// OperationsShortcuts.
+	Keys map[string]string
+
+	// sources tracks which layer last set each field, keyed by field name,
+	// for `config doctor` to report. Not part of the persisted format.
+	sources map[string]string
+	// tomlPath is the resolved path `Save` writes back to.
+	tomlPath string
 }
 
-// Load reads configuration from environment and .env file
+// tomlFile mirrors the on-disk config.toml structure.
+type tomlFile struct {
+	API struct {
+		BaseURL   string `toml:"base_url"`
+		APIKey    string `toml:"api_key"`
+		PoolSize  int    `toml:"pool_size"`
+		RateLimit int    `toml:"rate_limit"`
+	} `toml:"api"`
+	SSH struct {
+		Username        string `toml:"username"`
+		Password        string `toml:"password"`
+		UseKeys         bool   `toml:"use_keys"`
+		InsecureHostKey bool   `toml:"insecure_host_key"`
+		KnownHostsFile  string `toml:"known_hosts_file"`
+	} `toml:"ssh"`
+	UI struct {
+		Theme           string `toml:"theme"`
+		StylesetPath    string `toml:"styleset_path"`
+		BannerFile      string `toml:"banner_file"`
+		RefreshInterval string `toml:"refresh_interval"`
+	} `toml:"ui"`
+	Cache struct {
+		Dir string `toml:"dir"`
+		TTL string `toml:"ttl"`
+	} `toml:"cache"`
+	Metrics struct {
+		Enabled bool   `toml:"enabled"`
+		Addr    string `toml:"addr"`
+		Pprof   bool   `toml:"pprof"`
+	} `toml:"metrics"`
+	Telemetry struct {
+		Enabled      bool   `toml:"enabled"`
+		Interval     string `toml:"interval"`
+		CollectorURL string `toml:"collector_url"`
+		BearerToken  string `toml:"bearer_token"`
+		Gzip         bool   `toml:"gzip"`
+		SpoolDir     string `toml:"spool_dir"`
+		RedactFields string `toml:"redact_fields"`
+	} `toml:"telemetry"`
+	Store struct {
+		Backend          string `toml:"backend"`
+		Path             string `toml:"path"`
+		RedisAddr        string `toml:"redis_addr"`
+		RedisPassword    string `toml:"redis_password"`
+		RedisDB          int    `toml:"redis_db"`
+		RedisMaxIdle     int    `toml:"redis_max_idle"`
+		RedisMaxActive   int    `toml:"redis_max_active"`
+		RedisIdleTimeout string `toml:"redis_idle_timeout"`
+		RedisDialTimeout string `toml:"redis_dial_timeout"`
+	} `toml:"store"`
+	Shutdown struct {
+		Timeout string `toml:"timeout"`
+	} `toml:"shutdown"`
+	Statsd struct {
+		Enabled bool   `toml:"enabled"`
+		Host    string `toml:"host"`
+		Port    int    `toml:"port"`
+	} `toml:"statsd"`
+	Batch struct {
+		MaxParallelOps int    `toml:"max_parallel_ops"`
+		RetryTimeout   string `toml:"retry_timeout"`
+	} `toml:"batch"`
+	Audit struct {
+		SinkKind     string `toml:"sink_kind"`
+		Path         string `toml:"path"`
+		MaxSizeBytes int64  `toml:"max_size_bytes"`
+		MaxAge       string `toml:"max_age"`
+		MaxBackups   int    `toml:"max_backups"`
+		WebhookURL   string `toml:"webhook_url"`
+		WebhookToken string `toml:"webhook_token"`
+	} `toml:"audit"`
+	Keys map[string]string `toml:"keys"`
+}
+
+// defaults returns the built-in configuration, the first and lowest-priority
+// layer in the precedence chain.
+func defaults() *Config {
+	return &Config{
+		SSHUsername:              "broala",
+		Theme:                    "dark",
+		SensorsFile:              "../.sensors",
+		OfflineCacheDir:          filepath.Join(os.Getenv("HOME"), ".ec2sensor", "cache"),
+		CacheTTL:                 5 * time.Minute,
+		StoreBackend:             "memory",
+		StorePath:                filepath.Join(os.Getenv("HOME"), ".ec2sensor", "sensors.db"),
+		RedisAddr:                "localhost:6379",
+		RedisMaxIdle:             3,
+		RedisMaxActive:           10,
+		RedisIdleTimeout:         5 * time.Minute,
+		RedisDialTimeout:         5 * time.Second,
+		MetricsAddr:              ":9101",
+		SSHKnownHostsFile:        filepath.Join(os.Getenv("HOME"), ".ssh", "known_hosts"),
+		TelemetryInterval:        5 * time.Minute,
+		TelemetrySpoolDir:        filepath.Join(os.Getenv("HOME"), ".ec2sensor", "telemetry-spool"),
+		ShutdownTimeout:          10 * time.Second,
+		DashboardRefreshInterval: 2 * time.Second,
+		StatsdHost:               "localhost",
+		StatsdPort:               8125,
+		MaxParallelOps:           3,
+		BatchRetryTimeout:        2 * time.Minute,
+		AuditSinkKind:            "file",
+		AuditPath:                filepath.Join(os.Getenv("HOME"), ".ec2sensor", "audit.log"),
+		AuditMaxSizeBytes:        10 * 1024 * 1024,
+		AuditMaxAge:              24 * time.Hour,
+		AuditMaxBackups:          5,
+		Keys:                     map[string]string{},
+		sources:                  map[string]string{},
+	}
+}
+
+// configPath resolves the config.toml location: $XDG_CONFIG_HOME/ec2sensor/config.toml,
+// falling back to ~/.config/ec2sensor/config.toml.
+func configPath() string {
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		return filepath.Join(xdg, "ec2sensor", "config.toml")
+	}
+	return filepath.Join(os.Getenv("HOME"), ".config", "ec2sensor", "config.toml")
+}
+
+// Load builds the effective configuration by layering, in increasing
+// priority: built-in defaults, config.toml, .env files (cwd and parent),
+// environment variables, and finally CLI flags (os.Args).
 func Load() (*Config, error) {
-	// Try to load .env file from current directory and parent
+	return LoadWithArgs(os.Args[1:])
+}
+
+// LoadWithArgs is like Load but takes the CLI arguments explicitly, so
+// callers (and the `config doctor` subcommand) can parameterize the flags
+// layer without depending on the real process arguments.
+func LoadWithArgs(args []string) (*Config, error) {
+	cfg := defaults()
+	cfg.tomlPath = configPath()
+
+	cfg.applyTOML(cfg.tomlPath)
+	cfg.applyDotenv()
+	cfg.applyEnv()
+	cfg.applyFlags(args)
+
+	return cfg, nil
+}
+
+func (c *Config) set(field, value, source string) {
+	if value == "" {
+		return
+	}
+	switch field {
+	case "APIBaseURL":
+		c.APIBaseURL = value
+	case "APIKey":
+		c.APIKey = value
+	case "SSHUsername":
+		c.SSHUsername = value
+	case "SSHPassword":
+		c.SSHPassword = value
+	case "SSHKnownHostsFile":
+		c.SSHKnownHostsFile = value
+	case "Theme":
+		c.Theme = value
+		c.ThemeExplicit = true
+	case "StylesetPath":
+		c.StylesetPath = value
+	case "BannerFile":
+		c.BannerFile = value
+	case "SensorsFile":
+		c.SensorsFile = value
+	case "OfflineCacheDir":
+		c.OfflineCacheDir = value
+	case "StoreBackend":
+		c.StoreBackend = value
+	case "StorePath":
+		c.StorePath = value
+	case "RedisAddr":
+		c.RedisAddr = value
+	case "RedisPassword":
+		c.RedisPassword = value
+	case "MetricsAddr":
+		c.MetricsAddr = value
+	case "TelemetryCollectorURL":
+		c.TelemetryCollectorURL = value
+	case "TelemetryBearerToken":
+		c.TelemetryBearerToken = value
+	case "TelemetrySpoolDir":
+		c.TelemetrySpoolDir = value
+	case "TelemetryRedactFields":
+		c.TelemetryRedactFields = value
+	case "StatsdHost":
+		c.StatsdHost = value
+	case "AuditSinkKind":
+		c.AuditSinkKind = value
+	case "AuditPath":
+		c.AuditPath = value
+	case "AuditWebhookURL":
+		c.AuditWebhookURL = value
+	case "AuditWebhookToken":
+		c.AuditWebhookToken = value
+	}
+	if value != "" {
+		c.sources[field] = source
+	}
+}
+
+// applyTOML reads the config.toml layer, if present. A missing file is not
+// an error - it simply means this layer contributes nothing.
+func (c *Config) applyTOML(path string) {
+	var tf tomlFile
+	if _, err := toml.DecodeFile(path, &tf); err != nil {
+		return
+	}
+
+	c.set("APIBaseURL", tf.API.BaseURL, "config.toml")
+	c.set("APIKey", tf.API.APIKey, "config.toml")
+	if tf.API.PoolSize != 0 {
+		c.APIPoolSize = tf.API.PoolSize
+		c.sources["APIPoolSize"] = "config.toml"
+	}
+	if tf.API.RateLimit != 0 {
+		c.APIRateLimit = tf.API.RateLimit
+		c.sources["APIRateLimit"] = "config.toml"
+	}
+	c.set("SSHUsername", tf.SSH.Username, "config.toml")
+	c.set("SSHPassword", tf.SSH.Password, "config.toml")
+	if tf.SSH.UseKeys {
+		c.SSHUseKeys = true
+		c.sources["SSHUseKeys"] = "config.toml"
+	}
+	if tf.SSH.InsecureHostKey {
+		c.SSHInsecureHostKey = true
+		c.sources["SSHInsecureHostKey"] = "config.toml"
+	}
+	c.set("SSHKnownHostsFile", tf.SSH.KnownHostsFile, "config.toml")
+	c.set("Theme", tf.UI.Theme, "config.toml")
+	c.set("StylesetPath", tf.UI.StylesetPath, "config.toml")
+	c.set("BannerFile", tf.UI.BannerFile, "config.toml")
+	if tf.UI.RefreshInterval != "" {
+		if d, err := time.ParseDuration(tf.UI.RefreshInterval); err == nil {
+			c.DashboardRefreshInterval = d
+			c.sources["DashboardRefreshInterval"] = "config.toml"
+		}
+	}
+	c.set("OfflineCacheDir", tf.Cache.Dir, "config.toml")
+	if tf.Cache.TTL != "" {
+		if d, err := time.ParseDuration(tf.Cache.TTL); err == nil {
+			c.CacheTTL = d
+			c.sources["CacheTTL"] = "config.toml"
+		}
+	}
+	for k, v := range tf.Keys {
+		c.Keys[k] = v
+		c.sources["Keys."+k] = "config.toml"
+	}
+
+	if tf.Metrics.Enabled {
+		c.MetricsEnabled = true
+		c.sources["MetricsEnabled"] = "config.toml"
+	}
+	c.set("MetricsAddr", tf.Metrics.Addr, "config.toml")
+	if tf.Metrics.Pprof {
+		c.PprofEnabled = true
+		c.sources["PprofEnabled"] = "config.toml"
+	}
+
+	if tf.Telemetry.Enabled {
+		c.TelemetryEnabled = true
+		c.sources["TelemetryEnabled"] = "config.toml"
+	}
+	if tf.Telemetry.Interval != "" {
+		if d, err := time.ParseDuration(tf.Telemetry.Interval); err == nil {
+			c.TelemetryInterval = d
+			c.sources["TelemetryInterval"] = "config.toml"
+		}
+	}
+	c.set("TelemetryCollectorURL", tf.Telemetry.CollectorURL, "config.toml")
+	c.set("TelemetryBearerToken", tf.Telemetry.BearerToken, "config.toml")
+	if tf.Telemetry.Gzip {
+		c.TelemetryGzip = true
+		c.sources["TelemetryGzip"] = "config.toml"
+	}
+	c.set("TelemetrySpoolDir", tf.Telemetry.SpoolDir, "config.toml")
+	c.set("TelemetryRedactFields", tf.Telemetry.RedactFields, "config.toml")
+
+	if tf.Shutdown.Timeout != "" {
+		if d, err := time.ParseDuration(tf.Shutdown.Timeout); err == nil {
+			c.ShutdownTimeout = d
+			c.sources["ShutdownTimeout"] = "config.toml"
+		}
+	}
+
+	if tf.Statsd.Enabled {
+		c.StatsdEnabled = true
+		c.sources["StatsdEnabled"] = "config.toml"
+	}
+	c.set("StatsdHost", tf.Statsd.Host, "config.toml")
+	if tf.Statsd.Port != 0 {
+		c.StatsdPort = tf.Statsd.Port
+		c.sources["StatsdPort"] = "config.toml"
+	}
+
+	if tf.Batch.MaxParallelOps != 0 {
+		c.MaxParallelOps = tf.Batch.MaxParallelOps
+		c.sources["MaxParallelOps"] = "config.toml"
+	}
+	if tf.Batch.RetryTimeout != "" {
+		if d, err := time.ParseDuration(tf.Batch.RetryTimeout); err == nil {
+			c.BatchRetryTimeout = d
+			c.sources["BatchRetryTimeout"] = "config.toml"
+		}
+	}
+
+	c.set("AuditSinkKind", tf.Audit.SinkKind, "config.toml")
+	c.set("AuditPath", tf.Audit.Path, "config.toml")
+	if tf.Audit.MaxSizeBytes != 0 {
+		c.AuditMaxSizeBytes = tf.Audit.MaxSizeBytes
+		c.sources["AuditMaxSizeBytes"] = "config.toml"
+	}
+	if tf.Audit.MaxAge != "" {
+		if d, err := time.ParseDuration(tf.Audit.MaxAge); err == nil {
+			c.AuditMaxAge = d
+			c.sources["AuditMaxAge"] = "config.toml"
+		}
+	}
+	if tf.Audit.MaxBackups != 0 {
+		c.AuditMaxBackups = tf.Audit.MaxBackups
+		c.sources["AuditMaxBackups"] = "config.toml"
+	}
+	c.set("AuditWebhookURL", tf.Audit.WebhookURL, "config.toml")
+	c.set("AuditWebhookToken", tf.Audit.WebhookToken, "config.toml")
+
+	c.set("StoreBackend", tf.Store.Backend, "config.toml")
+	c.set("StorePath", tf.Store.Path, "config.toml")
+	c.set("RedisAddr", tf.Store.RedisAddr, "config.toml")
+	c.set("RedisPassword", tf.Store.RedisPassword, "config.toml")
+	if tf.Store.RedisDB != 0 {
+		c.RedisDB = tf.Store.RedisDB
+		c.sources["RedisDB"] = "config.toml"
+	}
+	if tf.Store.RedisMaxIdle != 0 {
+		c.RedisMaxIdle = tf.Store.RedisMaxIdle
+		c.sources["RedisMaxIdle"] = "config.toml"
+	}
+	if tf.Store.RedisMaxActive != 0 {
+		c.RedisMaxActive = tf.Store.RedisMaxActive
+		c.sources["RedisMaxActive"] = "config.toml"
+	}
+	if tf.Store.RedisIdleTimeout != "" {
+		if d, err := time.ParseDuration(tf.Store.RedisIdleTimeout); err == nil {
+			c.RedisIdleTimeout = d
+			c.sources["RedisIdleTimeout"] = "config.toml"
+		}
+	}
+	if tf.Store.RedisDialTimeout != "" {
+		if d, err := time.ParseDuration(tf.Store.RedisDialTimeout); err == nil {
+			c.RedisDialTimeout = d
+			c.sources["RedisDialTimeout"] = "config.toml"
+		}
+	}
+}
+
+// applyDotenv loads .env from the current directory and its parent, as the
+// original loader did, then folds any variables it set into the env layer.
+func (c *Config) applyDotenv() {
 	godotenv.Load(".env")
 	godotenv.Load("../.env")
+}
 
-	cfg := &Config{
-		APIBaseURL:      os.Getenv("EC2_SENSOR_BASE_URL"),
-		APIKey:          os.Getenv("EC2_SENSOR_API_KEY"),
-		SSHUsername:     getEnvDefault("SSH_USERNAME", "broala"),
-		SSHPassword:     os.Getenv("SSH_PASSWORD"),
-		Theme:           getEnvDefault("EC2SENSOR_THEME", "dark"),
-		SensorsFile:     getEnvDefault("SENSORS_FILE", "../.sensors"),
-		OfflineCacheDir: filepath.Join(os.Getenv("HOME"), ".ec2sensor", "cache"),
+// applyEnv overlays process environment variables, the next-highest layer.
+func (c *Config) applyEnv() {
+	c.set("APIBaseURL", os.Getenv("EC2_SENSOR_BASE_URL"), "env")
+	c.set("APIKey", os.Getenv("EC2_SENSOR_API_KEY"), "env")
+	c.set("SSHUsername", os.Getenv("SSH_USERNAME"), "env")
+	c.set("SSHPassword", os.Getenv("SSH_PASSWORD"), "env")
+	c.set("SSHKnownHostsFile", os.Getenv("SSH_KNOWN_HOSTS_FILE"), "env")
+	if os.Getenv("SSH_INSECURE_HOST_KEY") == "true" {
+		c.SSHInsecureHostKey = true
+		c.sources["SSHInsecureHostKey"] = "env"
+	}
+	c.set("Theme", os.Getenv("EC2SENSOR_THEME"), "env")
+	c.set("SensorsFile", os.Getenv("SENSORS_FILE"), "env")
+	c.set("MetricsAddr", os.Getenv("EC2SENSOR_METRICS_ADDR"), "env")
+	if os.Getenv("EC2SENSOR_METRICS_ENABLED") == "true" {
+		c.MetricsEnabled = true
+		c.sources["MetricsEnabled"] = "env"
+	}
+	if os.Getenv("EC2SENSOR_PPROF_ENABLED") == "true" {
+		c.PprofEnabled = true
+		c.sources["PprofEnabled"] = "env"
+	}
+	c.set("TelemetryCollectorURL", os.Getenv("EC2SENSOR_TELEMETRY_COLLECTOR_URL"), "env")
+	c.set("TelemetryBearerToken", os.Getenv("EC2SENSOR_TELEMETRY_BEARER_TOKEN"), "env")
+	c.set("TelemetrySpoolDir", os.Getenv("EC2SENSOR_TELEMETRY_SPOOL_DIR"), "env")
+	c.set("TelemetryRedactFields", os.Getenv("EC2SENSOR_TELEMETRY_REDACT_FIELDS"), "env")
+	if os.Getenv("EC2SENSOR_TELEMETRY_ENABLED") == "true" {
+		c.TelemetryEnabled = true
+		c.sources["TelemetryEnabled"] = "env"
+	}
+	if os.Getenv("EC2SENSOR_TELEMETRY_GZIP") == "true" {
+		c.TelemetryGzip = true
+		c.sources["TelemetryGzip"] = "env"
+	}
+	if v := os.Getenv("EC2SENSOR_TELEMETRY_INTERVAL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			c.TelemetryInterval = d
+			c.sources["TelemetryInterval"] = "env"
+		}
+	}
+	if v := os.Getenv("EC2SENSOR_SHUTDOWN_TIMEOUT"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			c.ShutdownTimeout = d
+			c.sources["ShutdownTimeout"] = "env"
+		}
+	}
+	if v := os.Getenv("EC2SENSOR_REFRESH_INTERVAL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			c.DashboardRefreshInterval = d
+			c.sources["DashboardRefreshInterval"] = "env"
+		}
+	}
+	if os.Getenv("EC2SENSOR_STATSD_ENABLED") == "true" {
+		c.StatsdEnabled = true
+		c.sources["StatsdEnabled"] = "env"
+	}
+	c.set("StatsdHost", os.Getenv("EC2SENSOR_STATSD_HOST"), "env")
+	if v := os.Getenv("EC2SENSOR_STATSD_PORT"); v != "" {
+		if port, err := strconv.Atoi(v); err == nil {
+			c.StatsdPort = port
+			c.sources["StatsdPort"] = "env"
+		}
+	}
+	if v := os.Getenv("EC2SENSOR_MAX_PARALLEL_OPS"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			c.MaxParallelOps = n
+			c.sources["MaxParallelOps"] = "env"
+		}
+	}
+	if v := os.Getenv("EC2SENSOR_BATCH_RETRY_TIMEOUT"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			c.BatchRetryTimeout = d
+			c.sources["BatchRetryTimeout"] = "env"
+		}
+	}
+	c.set("AuditSinkKind", os.Getenv("EC2SENSOR_AUDIT_SINK_KIND"), "env")
+	c.set("AuditPath", os.Getenv("EC2SENSOR_AUDIT_PATH"), "env")
+	c.set("AuditWebhookURL", os.Getenv("EC2SENSOR_AUDIT_WEBHOOK_URL"), "env")
+	c.set("AuditWebhookToken", os.Getenv("EC2SENSOR_AUDIT_WEBHOOK_TOKEN"), "env")
+	if v := os.Getenv("EC2SENSOR_AUDIT_MAX_SIZE_BYTES"); v != "" {
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			c.AuditMaxSizeBytes = n
+			c.sources["AuditMaxSizeBytes"] = "env"
+		}
+	}
+	if v := os.Getenv("EC2SENSOR_AUDIT_MAX_AGE"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			c.AuditMaxAge = d
+			c.sources["AuditMaxAge"] = "env"
+		}
+	}
+	if v := os.Getenv("EC2SENSOR_AUDIT_MAX_BACKUPS"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			c.AuditMaxBackups = n
+			c.sources["AuditMaxBackups"] = "env"
+		}
 	}
 
-	// Check for SSH keys
-	if cfg.SSHPassword == "" {
+	// Check for SSH keys, matching the original loader's auto-detection.
+	if c.SSHPassword == "" {
 		home := os.Getenv("HOME")
 		if _, err := os.Stat(filepath.Join(home, ".ssh", "id_rsa")); err == nil {
-			cfg.SSHUseKeys = true
+			c.SSHUseKeys = true
+			c.sources["SSHUseKeys"] = "env"
 		} else if _, err := os.Stat(filepath.Join(home, ".ssh", "id_ed25519")); err == nil {
-			cfg.SSHUseKeys = true
+			c.SSHUseKeys = true
+			c.sources["SSHUseKeys"] = "env"
 		}
 	}
+}
 
-	return cfg, nil
+// applyFlags overlays CLI flags, the highest-priority layer. Flags are
+// simple `--name=value` or `--name value` pairs; unrecognized flags are
+// ignored here since they may belong to a subcommand.
+func (c *Config) applyFlags(args []string) {
+	flags := map[string]*string{
+		"--api-base-url":            &c.APIBaseURL,
+		"--api-key":                 &c.APIKey,
+		"--theme":                   &c.Theme,
+		"--styleset-path":           &c.StylesetPath,
+		"--banner-file":             &c.BannerFile,
+		"--store-backend":           &c.StoreBackend,
+		"--store-path":              &c.StorePath,
+		"--redis-addr":              &c.RedisAddr,
+		"--metrics-addr":            &c.MetricsAddr,
+		"--telemetry-collector-url": &c.TelemetryCollectorURL,
+		"--telemetry-spool-dir":     &c.TelemetrySpoolDir,
+		"--statsd-host":             &c.StatsdHost,
+	}
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--insecure" {
+			c.SSHInsecureHostKey = true
+			c.sources["SSHInsecureHostKey"] = "flag"
+			continue
+		}
+		if arg == "--pprof" {
+			c.PprofEnabled = true
+			c.sources["PprofEnabled"] = "flag"
+			continue
+		}
+		if arg == "--statsd" {
+			c.StatsdEnabled = true
+			c.sources["StatsdEnabled"] = "flag"
+			continue
+		}
+		name, value, hasValue := strings.Cut(arg, "=")
+		if name == "--statsd-port" {
+			if !hasValue {
+				if i+1 >= len(args) {
+					continue
+				}
+				i++
+				value = args[i]
+			}
+			if port, err := strconv.Atoi(value); err == nil {
+				c.StatsdPort = port
+				c.sources["StatsdPort"] = "flag"
+			}
+			continue
+		}
+		target, ok := flags[name]
+		if !ok {
+			continue
+		}
+		if !hasValue {
+			if i+1 >= len(args) {
+				continue
+			}
+			i++
+			value = args[i]
+		}
+		*target = value
+		c.sources[flagField(name)] = "flag"
+		if name == "--theme" {
+			c.ThemeExplicit = true
+		}
+	}
+}
+
+func flagField(name string) string {
+	switch name {
+	case "--api-base-url":
+		return "APIBaseURL"
+	case "--api-key":
+		return "APIKey"
+	case "--theme":
+		return "Theme"
+	case "--styleset-path":
+		return "StylesetPath"
+	case "--banner-file":
+		return "BannerFile"
+	case "--store-backend":
+		return "StoreBackend"
+	case "--store-path":
+		return "StorePath"
+	case "--redis-addr":
+		return "RedisAddr"
+	case "--metrics-addr":
+		return "MetricsAddr"
+	case "--telemetry-collector-url":
+		return "TelemetryCollectorURL"
+	case "--telemetry-spool-dir":
+		return "TelemetrySpoolDir"
+	case "--statsd-host":
+		return "StatsdHost"
+	default:
+		return name
+	}
+}
+
+// Save writes the current effective configuration back to config.toml, so
+// changes made at runtime (e.g. the `t` cycle key) survive a restart. Every
+// section is rewritten from c, including values that came from .env, the
+// environment or flags; earlier file contents are not kept for any field
+// tomlFile models.
+func (c *Config) Save() error {
+	var tf tomlFile
+	toml.DecodeFile(c.tomlPath, &tf) // best-effort read; every field below is then overwritten from c
+
+	tf.API.BaseURL = c.APIBaseURL
+	tf.API.APIKey = c.APIKey
+	tf.API.PoolSize = c.APIPoolSize
+	tf.API.RateLimit = c.APIRateLimit
+	tf.SSH.Username = c.SSHUsername
+	tf.SSH.Password = c.SSHPassword
+	tf.SSH.UseKeys = c.SSHUseKeys
+	tf.SSH.InsecureHostKey = c.SSHInsecureHostKey
+	tf.SSH.KnownHostsFile = c.SSHKnownHostsFile
+	tf.UI.Theme = c.Theme
+	tf.UI.StylesetPath = c.StylesetPath
+	tf.UI.BannerFile = c.BannerFile
+	tf.UI.RefreshInterval = c.DashboardRefreshInterval.String()
+	tf.Cache.Dir = c.OfflineCacheDir
+	tf.Cache.TTL = c.CacheTTL.String()
+	tf.Store.Backend = c.StoreBackend
+	tf.Store.Path = c.StorePath
+	tf.Store.RedisAddr = c.RedisAddr
+	tf.Store.RedisPassword = c.RedisPassword
+	tf.Store.RedisDB = c.RedisDB
+	tf.Store.RedisMaxIdle = c.RedisMaxIdle
+	tf.Store.RedisMaxActive = c.RedisMaxActive
+	tf.Store.RedisIdleTimeout = c.RedisIdleTimeout.String()
+	tf.Store.RedisDialTimeout = c.RedisDialTimeout.String()
+	tf.Keys = c.Keys
+	tf.Metrics.Enabled = c.MetricsEnabled
+	tf.Metrics.Addr = c.MetricsAddr
+	tf.Metrics.Pprof = c.PprofEnabled
+	tf.Telemetry.Enabled = c.TelemetryEnabled
+	tf.Telemetry.Interval = c.TelemetryInterval.String()
+	tf.Telemetry.CollectorURL = c.TelemetryCollectorURL
+	tf.Telemetry.BearerToken = c.TelemetryBearerToken
+	tf.Telemetry.Gzip = c.TelemetryGzip
+	tf.Telemetry.SpoolDir = c.TelemetrySpoolDir
+	tf.Telemetry.RedactFields = c.TelemetryRedactFields
+	tf.Shutdown.Timeout = c.ShutdownTimeout.String()
+	tf.Statsd.Enabled = c.StatsdEnabled
+	tf.Statsd.Host = c.StatsdHost
+	tf.Statsd.Port = c.StatsdPort
+	tf.Batch.MaxParallelOps = c.MaxParallelOps
+	tf.Batch.RetryTimeout = c.BatchRetryTimeout.String()
+	tf.Audit.SinkKind = c.AuditSinkKind
+	tf.Audit.Path = c.AuditPath
+	tf.Audit.MaxSizeBytes = c.AuditMaxSizeBytes
+	tf.Audit.MaxAge = c.AuditMaxAge.String()
+	tf.Audit.MaxBackups = c.AuditMaxBackups
+	tf.Audit.WebhookURL = c.AuditWebhookURL
+	tf.Audit.WebhookToken = c.AuditWebhookToken
+
+	if err := os.MkdirAll(filepath.Dir(c.tomlPath), 0755); err != nil {
+		return fmt.Errorf("create config dir: %w", err)
+	}
+
+	f, err := os.Create(c.tomlPath)
+	if err != nil {
+		return fmt.Errorf("create config file: %w", err)
+	}
+	defer f.Close()
+
+	return toml.NewEncoder(f).Encode(tf)
+}
+
+// Doctor prints the effective resolved configuration and, for each value,
+// which layer supplied it ("default" if no layer overrode it).
+func (c *Config) Doctor() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "EC2 Sensor Manager - effective configuration\n\n")
+
+	line := func(field, value string) {
+		source := c.sources[field]
+		if source == "" {
+			source = "default"
+		}
+		fmt.Fprintf(&b, "  %-20s %-40s (%s)\n", field, value, source)
+	}
+
+	line("APIBaseURL", c.APIBaseURL)
+	line("APIKey", maskSecret(c.APIKey))
+	line("APIPoolSize", fmt.Sprintf("%d", c.APIPoolSize))
+	line("APIRateLimit", fmt.Sprintf("%d", c.APIRateLimit))
+	line("SSHUsername", c.SSHUsername)
+	line("SSHPassword", maskSecret(c.SSHPassword))
+	line("SSHInsecureHostKey", fmt.Sprintf("%t", c.SSHInsecureHostKey))
+	if !c.SSHInsecureHostKey {
+		line("SSHKnownHostsFile", c.SSHKnownHostsFile)
+	}
+	line("Theme", c.Theme)
+	line("StylesetPath", c.StylesetPath)
+	line("BannerFile", c.BannerFile)
+	line("SensorsFile", c.SensorsFile)
+	line("OfflineCacheDir", c.OfflineCacheDir)
+	line("CacheTTL", c.CacheTTL.String())
+	line("StoreBackend", c.StoreBackend)
+	line("StorePath", c.StorePath)
+	if c.StoreBackend == "redis" {
+		line("RedisAddr", c.RedisAddr)
+		line("RedisPassword", maskSecret(c.RedisPassword))
+	}
+	line("MetricsEnabled", fmt.Sprintf("%t", c.MetricsEnabled))
+	if c.MetricsEnabled {
+		line("MetricsAddr", c.MetricsAddr)
+		line("PprofEnabled", fmt.Sprintf("%t", c.PprofEnabled))
+	}
+	line("TelemetryEnabled", fmt.Sprintf("%t", c.TelemetryEnabled))
+	if c.TelemetryEnabled {
+		line("TelemetryInterval", c.TelemetryInterval.String())
+		line("TelemetryCollectorURL", c.TelemetryCollectorURL)
+		line("TelemetryBearerToken", maskSecret(c.TelemetryBearerToken))
+		line("TelemetryGzip", fmt.Sprintf("%t", c.TelemetryGzip))
+		line("TelemetrySpoolDir", c.TelemetrySpoolDir)
+		line("TelemetryRedactFields", c.TelemetryRedactFields)
+	}
+	line("ShutdownTimeout", c.ShutdownTimeout.String())
+	line("DashboardRefreshInterval", c.DashboardRefreshInterval.String())
+	line("StatsdEnabled", fmt.Sprintf("%t", c.StatsdEnabled))
+	if c.StatsdEnabled {
+		line("StatsdHost", c.StatsdHost)
+		line("StatsdPort", fmt.Sprintf("%d", c.StatsdPort))
+	}
+	line("MaxParallelOps", fmt.Sprintf("%d", c.MaxParallelOps))
+	line("BatchRetryTimeout", c.BatchRetryTimeout.String())
+	line("AuditSinkKind", c.AuditSinkKind)
+	if c.AuditSinkKind != "none" {
+		line("AuditPath", c.AuditPath)
+		line("AuditMaxSizeBytes", fmt.Sprintf("%d", c.AuditMaxSizeBytes))
+		line("AuditMaxAge", c.AuditMaxAge.String())
+		line("AuditMaxBackups", fmt.Sprintf("%d", c.AuditMaxBackups))
+	}
+	if c.AuditSinkKind == "webhook" {
+		line("AuditWebhookURL", c.AuditWebhookURL)
+		line("AuditWebhookToken", maskSecret(c.AuditWebhookToken))
+	}
+
+	fmt.Fprintf(&b, "\nconfig.toml: %s\n", c.tomlPath)
+	return b.String()
+}
+
+func maskSecret(v string) string {
+	if v == "" {
+		return ""
+	}
+	return "***"
 }
 
 // Validate checks that required configuration is present
@@ -67,9 +880,8 @@ func (c *Config) Validate() error {
 	return nil
 }
 
-func getEnvDefault(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
-	}
-	return defaultValue
+// Path returns the config.toml path this Config was loaded from (and Save
+// writes back to), for callers that need to watch it for changes.
+func (c *Config) Path() string {
+	return c.tomlPath
 }
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// ReloadResult is what a Watcher emits each time the config file changes:
+// either a freshly reloaded Config, or the error Load returned while
+// trying.
+type ReloadResult struct {
+	Config *Config
+	Err    error
+}
+
+// Watcher reloads the config file on every change and publishes the result
+// on Events, so a long-running process can pick up edits (theme, API
+// endpoint, SSH key path, sensors file, ...) without restarting.
+type Watcher struct {
+	fsw    *fsnotify.Watcher
+	path   string
+	events chan ReloadResult
+}
+
+// WatchFile starts watching path's parent directory - not the file itself,
+// so edits that replace it via rename-into-place (vim's default save
+// behavior: RENAME the old inode away, then CREATE a new one at the same
+// name) are still seen, not just in-place WRITEs.
+func WatchFile(path string) (*Watcher, error) {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("create config watcher: %w", err)
+	}
+	if err := fsw.Add(filepath.Dir(path)); err != nil {
+		fsw.Close()
+		return nil, fmt.Errorf("watch config dir: %w", err)
+	}
+
+	w := &Watcher{fsw: fsw, path: filepath.Clean(path), events: make(chan ReloadResult)}
+	go w.run()
+	return w, nil
+}
+
+// Events returns the channel of ReloadResults. It's closed once Close is
+// called.
+func (w *Watcher) Events() <-chan ReloadResult {
+	return w.events
+}
+
+// Close stops the underlying fsnotify watcher and closes Events().
+func (w *Watcher) Close() error {
+	return w.fsw.Close()
+}
+
+// TriggerReload reloads the config file and publishes the result on Events,
+// the same as an fsnotify-detected edit would - for a caller (SIGHUP) that
+// wants a reload applied without waiting for the file to actually change on
+// disk.
+func (w *Watcher) TriggerReload() {
+	cfg, err := Load()
+	w.events <- ReloadResult{Config: cfg, Err: err}
+}
+
+func (w *Watcher) run() {
+	defer close(w.events)
+
+	for {
+		select {
+		case event, ok := <-w.fsw.Events:
+			if !ok {
+				return
+			}
+			if filepath.Clean(event.Name) != w.path {
+				continue
+			}
+			// A bare REMOVE happens mid-rename, before the replacement
+			// file lands - there's nothing to reload yet, so it's
+			// skipped; the CREATE that follows triggers the reload.
+			if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+				continue
+			}
+			cfg, err := Load()
+			w.events <- ReloadResult{Config: cfg, Err: err}
+		case err, ok := <-w.fsw.Errors:
+			if !ok {
+				return
+			}
+			w.events <- ReloadResult{Err: err}
+		}
+	}
+}
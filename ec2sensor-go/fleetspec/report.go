@@ -0,0 +1,58 @@
+package fleetspec
+
+// Action is a single reconcile step for one sensor: a comparison between
+// current and desired state, and, outside dry-run, the outcome of applying
+// it.
+type Action struct {
+	// Step names the aspect of sensor state this action covers: "version",
+	// "release_channel", "enable_features", "fleet_manager", or
+	// "rotate_admin_password".
+	Step    string
+	Current string
+	Desired string
+	// Skipped is true when Current already matched Desired, so nothing was
+	// applied.
+	Skipped bool
+	// Applied is true once this action has actually been run; false during
+	// planning, dry-run, or after a Skipped/errored action.
+	Applied bool
+	Err     error
+}
+
+// SensorReport is the plan (and, once applied, the result) for a single
+// sensor.
+type SensorReport struct {
+	Name    string
+	IP      string
+	Actions []Action
+	// Err is set if the sensor couldn't be reached at all, short-circuiting
+	// every Action.
+	Err error
+}
+
+// Drifted reports whether any action in r would change sensor state.
+func (r SensorReport) Drifted() bool {
+	for _, a := range r.Actions {
+		if !a.Skipped {
+			return true
+		}
+	}
+	return false
+}
+
+// Report is the result of a full Reconcile pass across a fleet.
+type Report struct {
+	Sensors []SensorReport
+}
+
+// Drifted returns the sensors whose plan contains at least one non-skipped
+// action, so a rerun can focus only on what's still out of sync.
+func (r Report) Drifted() []SensorReport {
+	var out []SensorReport
+	for _, s := range r.Sensors {
+		if s.Drifted() {
+			out = append(out, s)
+		}
+	}
+	return out
+}
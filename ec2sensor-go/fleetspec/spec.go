@@ -0,0 +1,56 @@
+// Package fleetspec describes the desired state of a fleet of sensors as a
+// YAML document, and the plan/result of reconciling a fleet towards it.
+// The reconciliation itself lives in ssh.Client.Reconcile, which drives the
+// existing SSH primitives (EnableFeatures, RunUpgradeSpecific,
+// AddToFleetManager, GetReleaseChannel) using the types defined here.
+package fleetspec
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Spec describes the desired state of a fleet of sensors, keyed by sensor
+// name.
+type Spec struct {
+	Sensors map[string]SensorSpec `yaml:"sensors"`
+}
+
+// SensorSpec is the desired state for a single sensor.
+type SensorSpec struct {
+	// Version is the target sensor version; empty skips version management.
+	Version string `yaml:"version"`
+	// Repo is the package repository RunUpgradeSpecific pulls Version from;
+	// required when Version is set.
+	Repo string `yaml:"repo"`
+	// ReleaseChannel is the desired corelightctl.yaml release channel.
+	ReleaseChannel string `yaml:"release_channel"`
+	// EnableFeatures, when true, ensures http.access/yara/suricata/smartpcap
+	// are enabled via EnableFeatures. The underlying broala-config commands
+	// are themselves idempotent, so reapplying is safe.
+	EnableFeatures bool `yaml:"enable_features"`
+	// FleetManager, when true, ensures the sensor is registered with the
+	// fleet manager via AddToFleetManager.
+	FleetManager bool `yaml:"fleet_manager"`
+	// RotateAdminPassword requests a fresh admin password on this pass.
+	// There's no primitive yet to perform the rotation, so this surfaces as
+	// a recorded action carrying ssh.ErrRotationNotImplemented rather than
+	// silently doing nothing.
+	RotateAdminPassword bool `yaml:"rotate_admin_password"`
+}
+
+// Load reads and parses a fleet spec from a YAML (or JSON, a YAML subset)
+// file at path.
+func Load(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read spec: %w", err)
+	}
+	var spec Spec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("parse spec: %w", err)
+	}
+	return &spec, nil
+}
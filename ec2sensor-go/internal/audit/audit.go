@@ -0,0 +1,121 @@
+// Package audit records a structured, durable trail of every sensor
+// operation's lifecycle - start, steps, and completion - independent of
+// whatever the TUI happens to have on screen at the time. The log slices
+// each operation's Update handler builds up for rendering (deployLogs,
+// upgradeLogs, ...) live only as long as the Model does; an audit Event is
+// written to a Sink (rotating file, JSON lines, syslog, or an HTTP webhook)
+// so it's greppable after the fact and still produced even when the user
+// has navigated to a different view.
+package audit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Level is an Event's severity, mirroring the ✓/⚠/✗ markers the progress
+// views already prefix their log lines with.
+type Level string
+
+const (
+	LevelInfo  Level = "info"
+	LevelWarn  Level = "warn"
+	LevelError Level = "error"
+)
+
+// Event is one structured record of an operation's progress: a start, a
+// step, or a completion/failure.
+type Event struct {
+	Timestamp  time.Time `json:"ts"`
+	SensorID   string    `json:"sensor_id"`
+	SensorIP   string    `json:"sensor_ip,omitempty"`
+	Operation  string    `json:"operation"`
+	Phase      string    `json:"phase,omitempty"`
+	Level      Level     `json:"level"`
+	Message    string    `json:"message"`
+	DurationMS int64     `json:"duration_ms,omitempty"`
+	Err        string    `json:"error,omitempty"`
+}
+
+// Sink publishes an Event somewhere durable. Implementations:
+// RotatingFileSink, JSONLSink, SyslogSink, WebhookSink.
+type Sink interface {
+	Write(ctx context.Context, event Event) error
+}
+
+// Logger emits Events to a Sink and keeps a bounded in-memory tail per
+// sensor/operation, so a progress view can show recent history by reading
+// Tail instead of owning its own log slice. A Write failure isn't returned
+// to Emit's caller - it's folded into the tail as a trailing note, so a
+// misconfigured sink shows up in the UI rather than failing silently.
+type Logger struct {
+	sink     Sink
+	tailSize int
+
+	mu    sync.Mutex
+	tails map[string][]string
+}
+
+// NewLogger builds a Logger writing to sink and keeping the last tailSize
+// lines per sensor/operation. A nil sink is valid: Emit still maintains
+// the in-memory tail, it just has nowhere durable to write it.
+func NewLogger(sink Sink, tailSize int) *Logger {
+	return &Logger{sink: sink, tailSize: tailSize, tails: map[string][]string{}}
+}
+
+// tailKey identifies one sensor's operation history, namespaced the same
+// way jobs.ID is so a deploy and a later enable-features run on the same
+// sensor don't share a tail.
+func tailKey(operation, sensorID string) string {
+	return operation + ":" + sensorID
+}
+
+// Emit writes event to the sink and appends its message to the
+// sensor/operation's tail buffer. event.Timestamp defaults to time.Now()
+// if unset.
+func (l *Logger) Emit(ctx context.Context, event Event) {
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+
+	line := event.Message
+	if l.sink != nil {
+		if err := l.sink.Write(ctx, event); err != nil {
+			line = fmt.Sprintf("%s (audit sink error: %v)", line, err)
+		}
+	}
+
+	key := tailKey(event.Operation, event.SensorID)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	tail := append(l.tails[key], line)
+	if l.tailSize > 0 && len(tail) > l.tailSize {
+		tail = tail[len(tail)-l.tailSize:]
+	}
+	l.tails[key] = tail
+}
+
+// Seed replaces the in-memory tail for sensorID's operation with lines,
+// without writing anything to the sink. It's for resuming an operation
+// from persisted state (opjournal) where lines already happened in a
+// prior process - re-emitting them through Emit would duplicate them in
+// the durable trail.
+func (l *Logger) Seed(operation, sensorID string, lines []string) {
+	tail := append([]string{}, lines...)
+	if l.tailSize > 0 && len(tail) > l.tailSize {
+		tail = tail[len(tail)-l.tailSize:]
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tails[tailKey(operation, sensorID)] = tail
+}
+
+// Tail returns the most recent lines emitted for sensorID's operation.
+func (l *Logger) Tail(operation, sensorID string) []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string{}, l.tails[tailKey(operation, sensorID)]...)
+}
@@ -0,0 +1,146 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// RotatingFileSink appends each Event as one JSON line to a file, rotating
+// it once it exceeds MaxSizeBytes or, failing that, once it's older than
+// MaxAge. Rotation renames the current file to path.1, shifting existing
+// backups up (path.1 -> path.2, ...), and drops anything beyond
+// MaxBackups.
+type RotatingFileSink struct {
+	path         string
+	maxSizeBytes int64
+	maxAge       time.Duration
+	maxBackups   int
+
+	mu sync.Mutex
+}
+
+// NewRotatingFileSink builds a RotatingFileSink appending to path.
+// maxSizeBytes <= 0 disables size-based rotation; maxAge <= 0 disables
+// age-based rotation; maxBackups <= 0 rotates by deleting the old file
+// instead of keeping it as a backup.
+func NewRotatingFileSink(path string, maxSizeBytes int64, maxAge time.Duration, maxBackups int) *RotatingFileSink {
+	return &RotatingFileSink{path: path, maxSizeBytes: maxSizeBytes, maxAge: maxAge, maxBackups: maxBackups}
+}
+
+// Write appends event to the sink's file as a single JSON line, rotating
+// first if the file has grown past MaxSizeBytes or aged past MaxAge.
+func (s *RotatingFileSink) Write(ctx context.Context, event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("marshal audit event: %w", err)
+	}
+	data = append(data, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("create audit log dir: %w", err)
+	}
+	if s.needsRotation() {
+		if err := s.rotate(); err != nil {
+			return fmt.Errorf("rotate audit log: %w", err)
+		}
+	}
+
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("open audit log: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(data); err != nil {
+		return fmt.Errorf("write audit log: %w", err)
+	}
+	return nil
+}
+
+// needsRotation reports whether the current file has crossed MaxSizeBytes
+// or MaxAge. Callers must hold s.mu. A missing file never needs rotation.
+func (s *RotatingFileSink) needsRotation() bool {
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return false
+	}
+	if s.maxSizeBytes > 0 && info.Size() >= s.maxSizeBytes {
+		return true
+	}
+	if s.maxAge > 0 && time.Since(info.ModTime()) >= s.maxAge {
+		return true
+	}
+	return false
+}
+
+// rotate shifts path -> path.1 -> path.2 ..., dropping anything beyond
+// MaxBackups. Callers must hold s.mu.
+func (s *RotatingFileSink) rotate() error {
+	if s.maxBackups <= 0 {
+		return os.Remove(s.path)
+	}
+
+	if oldest := fmt.Sprintf("%s.%d", s.path, s.maxBackups); fileExists(oldest) {
+		if err := os.Remove(oldest); err != nil {
+			return err
+		}
+	}
+	for i := s.maxBackups - 1; i >= 1; i-- {
+		from := fmt.Sprintf("%s.%d", s.path, i)
+		if !fileExists(from) {
+			continue
+		}
+		if err := os.Rename(from, fmt.Sprintf("%s.%d", s.path, i+1)); err != nil {
+			return err
+		}
+	}
+	return os.Rename(s.path, s.path+".1")
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// JSONLSink appends each Event as one JSON line to a file with no
+// rotation, for a caller that wants a single file to grep (or rotates it
+// externally via logrotate). Same shape as telemetry.FileSink, applied to
+// audit Events instead of telemetry Reports.
+type JSONLSink struct {
+	path string
+}
+
+// NewJSONLSink builds a JSONLSink appending to path.
+func NewJSONLSink(path string) *JSONLSink {
+	return &JSONLSink{path: path}
+}
+
+// Write appends event to the sink's file as a single JSON line.
+func (s *JSONLSink) Write(ctx context.Context, event Event) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("create audit log dir: %w", err)
+	}
+
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("open audit log: %w", err)
+	}
+	defer f.Close()
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("marshal audit event: %w", err)
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("write audit log: %w", err)
+	}
+	return nil
+}
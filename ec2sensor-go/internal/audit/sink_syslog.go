@@ -0,0 +1,40 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"log/syslog"
+)
+
+// SyslogSink forwards each Event to the local syslog daemon, tagged
+// "ec2sensor", at a priority mapped from Level.
+type SyslogSink struct {
+	w *syslog.Writer
+}
+
+// NewSyslogSink dials the local syslog daemon.
+func NewSyslogSink() (*SyslogSink, error) {
+	w, err := syslog.New(syslog.LOG_INFO, "ec2sensor")
+	if err != nil {
+		return nil, fmt.Errorf("connect to syslog: %w", err)
+	}
+	return &SyslogSink{w: w}, nil
+}
+
+// Write logs event as a single syslog line at a priority mapped from its
+// Level.
+func (s *SyslogSink) Write(ctx context.Context, event Event) error {
+	line := fmt.Sprintf("operation=%s sensor=%s phase=%s %s", event.Operation, event.SensorID, event.Phase, event.Message)
+	if event.Err != "" {
+		line += " error=" + event.Err
+	}
+
+	switch event.Level {
+	case LevelError:
+		return s.w.Err(line)
+	case LevelWarn:
+		return s.w.Warning(line)
+	default:
+		return s.w.Info(line)
+	}
+}
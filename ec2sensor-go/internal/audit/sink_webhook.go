@@ -0,0 +1,58 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// WebhookSink POSTs each Event as JSON to a central collector URL. Unlike
+// telemetry.HTTPSink, which batches and retries before spooling a whole
+// fleet Report to disk, an audit Event is small and frequent enough that a
+// failed POST is simply reported back to Logger.Emit (which already has
+// the event in its in-memory tail) rather than retried or spooled.
+type WebhookSink struct {
+	url         string
+	bearerToken string
+	httpClient  *http.Client
+}
+
+// NewWebhookSink builds a WebhookSink posting to url.
+func NewWebhookSink(url, bearerToken string) *WebhookSink {
+	return &WebhookSink{
+		url:         url,
+		bearerToken: bearerToken,
+		httpClient:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Write posts event to the collector.
+func (s *WebhookSink) Write(ctx context.Context, event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("marshal audit event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.bearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+s.bearerToken)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("post audit event: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("audit collector returned %s", resp.Status)
+	}
+	return nil
+}
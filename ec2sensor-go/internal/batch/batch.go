@@ -0,0 +1,154 @@
+// Package batch runs one Job per sensor under a bounded worker pool,
+// streaming lifecycle events as each job starts, reports progress, and
+// finishes. It generalizes the same bounded-concurrency shape ssh.Fleet
+// uses for "one SSH command per host" to "one arbitrary job per sensor", so
+// the TUI can fan a deploy/upgrade/delete/enable-features/add-to-fleet
+// operation out across a multi-select instead of acting on one sensor at a
+// time.
+package batch
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Phase identifies a stage in a Job's lifecycle, reported via Event.
+type Phase int
+
+const (
+	PhaseStarted Phase = iota
+	PhaseProgress
+	PhaseRetrying
+	PhaseDone
+	PhaseErr
+)
+
+// String renders p for display in a progress table.
+func (p Phase) String() string {
+	switch p {
+	case PhaseStarted:
+		return "started"
+	case PhaseProgress:
+		return "progress"
+	case PhaseRetrying:
+		return "retrying"
+	case PhaseDone:
+		return "done"
+	case PhaseErr:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
+
+// Event reports one lifecycle step of a single sensor's Job.
+type Event struct {
+	Sensor  string
+	Phase   Phase
+	Message string
+	Err     error
+}
+
+// Job is one sensor's unit of work. progress lets the job report
+// human-readable status lines (e.g. "waiting for SSH", "verifying version")
+// as PhaseProgress events while it runs; the returned error becomes a
+// single terminal PhaseDone or PhaseErr event.
+type Job func(ctx context.Context, progress func(message string)) error
+
+// Group runs one Job per sensor with concurrency bounded by maxParallel,
+// retrying a failed Job under Retry before giving up on it.
+type Group struct {
+	maxParallel int
+	retry       RetryPolicy
+}
+
+// NewGroup builds a Group bounding concurrency to maxParallel; 0 or
+// negative runs every job at once. Jobs aren't retried unless WithRetry is
+// also called.
+func NewGroup(maxParallel int) *Group {
+	return &Group{maxParallel: maxParallel}
+}
+
+// WithRetry sets g's RetryPolicy and returns g, for chaining onto NewGroup.
+func (g *Group) WithRetry(policy RetryPolicy) *Group {
+	g.retry = policy
+	return g
+}
+
+// Run starts jobs (keyed by sensor name) and returns a channel of Events.
+// The channel is closed once every job has reported a terminal event.
+// Cancelling ctx stops starting jobs still waiting for a pool slot, cuts
+// short a sensor's retry backoff, and reports it as PhaseErr with
+// ctx.Err() - this is the mechanism for a global retry timeout: give Run a
+// ctx built with context.WithTimeout, and every sensor still retrying past
+// that deadline is reported failed rather than kept waiting. A job already
+// running must check ctx itself to stop early; Run does not kill it.
+func (g *Group) Run(ctx context.Context, jobs map[string]Job) <-chan Event {
+	events := make(chan Event)
+
+	limit := g.maxParallel
+	if limit <= 0 {
+		limit = len(jobs)
+	}
+	if limit == 0 {
+		close(events)
+		return events
+	}
+	sem := make(chan struct{}, limit)
+	done := make(chan struct{})
+
+	for sensor, job := range jobs {
+		sensor, job := sensor, job
+		go func() {
+			defer func() { done <- struct{}{} }()
+
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				events <- Event{Sensor: sensor, Phase: PhaseErr, Err: ctx.Err()}
+				return
+			}
+			defer func() { <-sem }()
+
+			start := time.Now()
+			events <- Event{Sensor: sensor, Phase: PhaseStarted}
+			for attempt := 1; ; attempt++ {
+				err := job(ctx, func(message string) {
+					events <- Event{Sensor: sensor, Phase: PhaseProgress, Message: message}
+				})
+				if err == nil {
+					events <- Event{Sensor: sensor, Phase: PhaseDone}
+					return
+				}
+				if !g.retry.shouldRetry(attempt, time.Since(start)) {
+					events <- Event{Sensor: sensor, Phase: PhaseErr, Err: err}
+					return
+				}
+
+				wait := g.retry.delay(attempt)
+				events <- Event{
+					Sensor:  sensor,
+					Phase:   PhaseRetrying,
+					Message: fmt.Sprintf("attempt %d failed (%v), retrying in %s", attempt, err, wait.Round(time.Second)),
+					Err:     err,
+				}
+				select {
+				case <-time.After(wait):
+				case <-ctx.Done():
+					events <- Event{Sensor: sensor, Phase: PhaseErr, Err: ctx.Err()}
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		for range jobs {
+			<-done
+		}
+		close(events)
+	}()
+
+	return events
+}
@@ -0,0 +1,67 @@
+package batch
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// RetryPolicy controls how a Group retries a sensor's Job after it returns
+// an error. Delay grows from BaseDelay by Multiplier on each attempt,
+// capped at MaxDelay, with up to +/-Jitter fractional randomness layered
+// on top - the same shape as api.RetryPolicy, just applied to an arbitrary
+// Job's error instead of an HTTP response. Retrying stops once MaxRetries
+// attempts have been made or MaxElapsedTime has passed since the sensor's
+// first attempt, whichever comes first (MaxElapsedTime <= 0 disables that
+// check). The zero value disables retries entirely (MaxRetries 0).
+type RetryPolicy struct {
+	MaxRetries     int
+	BaseDelay      time.Duration
+	MaxDelay       time.Duration
+	Multiplier     float64
+	Jitter         float64
+	MaxElapsedTime time.Duration
+}
+
+// DefaultRetryPolicy is a reasonable default for SSH-backed batch jobs:
+// flaky connections are worth one retry (two attempts), but a sensor that's
+// genuinely unreachable shouldn't hold up the rest of the batch for long.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:     2,
+		BaseDelay:      2 * time.Second,
+		MaxDelay:       20 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+		MaxElapsedTime: 2 * time.Minute,
+	}
+}
+
+// delay returns how long to wait before the given attempt (1-indexed: the
+// delay before retrying after attempt 1 failed is delay(1)).
+func (p RetryPolicy) delay(attempt int) time.Duration {
+	d := float64(p.BaseDelay) * math.Pow(p.Multiplier, float64(attempt-1))
+	if p.MaxDelay > 0 && d > float64(p.MaxDelay) {
+		d = float64(p.MaxDelay)
+	}
+	if p.Jitter > 0 {
+		d *= 1 + p.Jitter*(rand.Float64()*2-1)
+	}
+	if d < 0 {
+		d = 0
+	}
+	return time.Duration(d)
+}
+
+// shouldRetry reports whether attempt (1-indexed, the attempt that just
+// failed) should be retried given elapsed time since the sensor's first
+// attempt.
+func (p RetryPolicy) shouldRetry(attempt int, elapsed time.Duration) bool {
+	if attempt >= p.MaxRetries {
+		return false
+	}
+	if p.MaxElapsedTime > 0 && elapsed >= p.MaxElapsedTime {
+		return false
+	}
+	return true
+}
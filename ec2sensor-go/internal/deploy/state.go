@@ -0,0 +1,126 @@
+// Package deploy formalizes the deploy flow's phase progression as an
+// explicit state machine, so main.go advances m.deployPhase by asking
+// "is this transition legal?" instead of the implicit "if the new phase
+// number is bigger, take it" comparison the TUI used to do inline.
+package deploy
+
+import (
+	"fmt"
+	"time"
+)
+
+// Phase is one step of a sensor deployment. Values are numbered in the
+// order a deploy is expected to pass through them, matching the
+// 1/2/3-style phase numbers checkDeployStatus has always reported so the
+// switch to a typed Phase didn't require renumbering anything main.go
+// already persists to opjournal.
+type Phase int
+
+const (
+	PhaseWaitingForInstance Phase = iota
+	PhaseSSHPort
+	PhaseSSHService
+	PhaseSeeding
+	PhaseFeaturesEnabled
+	PhaseComplete
+	PhaseFailed
+)
+
+// String renders p for log lines and progress views.
+func (p Phase) String() string {
+	switch p {
+	case PhaseWaitingForInstance:
+		return "waiting for instance"
+	case PhaseSSHPort:
+		return "SSH port"
+	case PhaseSSHService:
+		return "SSH service"
+	case PhaseSeeding:
+		return "seeding"
+	case PhaseFeaturesEnabled:
+		return "features enabled"
+	case PhaseComplete:
+		return "complete"
+	case PhaseFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+// All returns the happy-path phases in order, for a progress view to
+// render as a checklist. PhaseFailed is excluded - it's a terminal branch
+// off the happy path, not a step along it.
+func All() []Phase {
+	return []Phase{
+		PhaseWaitingForInstance,
+		PhaseSSHPort,
+		PhaseSSHService,
+		PhaseSeeding,
+		PhaseFeaturesEnabled,
+		PhaseComplete,
+	}
+}
+
+// Event is something that happened during a deploy that may advance its
+// Phase.
+type Event int
+
+const (
+	EventInstanceRunning Event = iota
+	EventSSHPortOpen
+	EventSSHServiceReady
+	EventSeedingComplete
+	EventFeaturesEnabled
+	EventFailed
+)
+
+// transitions is the single source of truth for which Phase an Event
+// leads to from a given Phase. PhaseSeeding accepts both EventSeedingComplete
+// (today's actual deploy path, which finishes as soon as system.seeded=1)
+// and EventFeaturesEnabled (reserved for if/when deploy is wired to
+// auto-run feature-enabling before declaring itself done) - both are legal
+// so that path can be turned on later without touching this table.
+var transitions = map[Phase]map[Event]Phase{
+	PhaseWaitingForInstance: {
+		EventInstanceRunning: PhaseSSHPort,
+		EventFailed:          PhaseFailed,
+	},
+	PhaseSSHPort: {
+		EventSSHPortOpen: PhaseSSHService,
+		EventFailed:      PhaseFailed,
+	},
+	PhaseSSHService: {
+		EventSSHServiceReady: PhaseSeeding,
+		EventFailed:          PhaseFailed,
+	},
+	PhaseSeeding: {
+		EventSeedingComplete: PhaseComplete,
+		EventFeaturesEnabled: PhaseFeaturesEnabled,
+		EventFailed:          PhaseFailed,
+	},
+	PhaseFeaturesEnabled: {
+		EventSeedingComplete: PhaseComplete,
+		EventFailed:          PhaseFailed,
+	},
+}
+
+// Transition returns the Phase that event leads to from from, rejecting
+// any (from, event) pair the deploy flow can't actually produce - e.g.
+// jumping straight from PhaseWaitingForInstance to PhaseComplete.
+func Transition(from Phase, event Event) (Phase, error) {
+	next, ok := transitions[from][event]
+	if !ok {
+		return from, fmt.Errorf("deploy: no transition from %s on event %d", from, event)
+	}
+	return next, nil
+}
+
+// PhaseChangedEvent records one Phase transition, for a progress view to
+// log or re-render in response to.
+type PhaseChangedEvent struct {
+	From   Phase
+	To     Phase
+	At     time.Time
+	Detail string
+}
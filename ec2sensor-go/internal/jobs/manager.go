@@ -0,0 +1,194 @@
+// Package jobs tracks the long-running background operations the TUI kicks
+// off (deploy, upgrade, enable-features, add-to-fleet, traffic scenarios) so
+// that detaching from an operation's progress view - by pressing ESC or
+// navigating elsewhere - doesn't lose its phase and log history. Unlike
+// opjournal, which exists so a deploy/upgrade survives a TUI restart, jobs
+// exists so it survives the user simply looking away: the Manager is an
+// in-memory registry of every operation's latest Snapshot, listable from a
+// "Background Jobs" view and re-attachable from there.
+package jobs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Kind identifies which operation a Snapshot tracks.
+type Kind string
+
+const (
+	KindDeploy         Kind = "deploy"
+	KindUpgrade        Kind = "upgrade"
+	KindEnableFeatures Kind = "enable_features"
+	KindAddToFleet     Kind = "add_to_fleet"
+	KindTraffic        Kind = "traffic"
+)
+
+// Status is a job's current lifecycle state.
+type Status string
+
+const (
+	StatusRunning  Status = "running"
+	StatusRetrying Status = "retrying"
+	StatusDone     Status = "done"
+	StatusFailed   Status = "failed"
+)
+
+// Snapshot is a job's state at the moment it was last reported. It carries
+// everything a progress view needs to redraw itself on re-attach: the phase
+// label and a tail of recent log lines, not just a pass/fail bit.
+type Snapshot struct {
+	ID         string    `json:"id"`
+	SensorName string    `json:"sensor_name"`
+	SensorIP   string    `json:"ip,omitempty"`
+	Kind       Kind      `json:"kind"`
+	Status     Status    `json:"status"`
+	StartTime  time.Time `json:"start_time"`
+	Phase      string    `json:"phase,omitempty"`
+	LogTail    []string  `json:"log_tail,omitempty"`
+	Err        string    `json:"err,omitempty"`
+	// Retries counts how many times this job has been retried from the
+	// Background Jobs view after failing.
+	Retries int `json:"retries,omitempty"`
+}
+
+// ID builds the Manager key a job of kind running against sensorName is
+// filed under: kind-namespaced so a sensor's deploy history and a later
+// enable-features run on the same sensor never collide.
+func ID(kind Kind, sensorName string) string {
+	return fmt.Sprintf("%s:%s", kind, sensorName)
+}
+
+// Manager is an in-memory registry of every job's latest Snapshot, persisted
+// to disk so a crash or CLI restart can at least list what was running
+// rather than losing the history outright. Unlike opjournal.Journal, which
+// re-reads the file on every call since it's only touched at phase
+// boundaries, Manager keeps jobs cached in memory because progress updates
+// arrive every few seconds while an operation is live.
+type Manager struct {
+	mu   sync.Mutex
+	path string
+	jobs map[string]Snapshot
+}
+
+// NewManager builds a Manager backed by path. Call Load once at startup to
+// populate it from a prior run.
+func NewManager(path string) *Manager {
+	return &Manager{path: path, jobs: map[string]Snapshot{}}
+}
+
+// DefaultPath resolves the job registry location: ~/.ec2sensor/jobs.json.
+// Deliberately not under $XDG_CONFIG_HOME/ec2sensor like opjournal's
+// operations.json - jobs.json is a cache of transient UI state, not
+// configuration, so it gets its own top-level dotfile instead.
+func DefaultPath() string {
+	return filepath.Join(os.Getenv("HOME"), ".ec2sensor", "jobs.json")
+}
+
+// Load populates the Manager from path. A missing file is not an error - it
+// just means no job has ever been recorded.
+func (m *Manager) Load() error {
+	data, err := os.ReadFile(m.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read job registry: %w", err)
+	}
+	jobs := map[string]Snapshot{}
+	if err := json.Unmarshal(data, &jobs); err != nil {
+		return fmt.Errorf("parse job registry: %w", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.jobs = jobs
+	return nil
+}
+
+// Update upserts snap and persists the registry.
+func (m *Manager) Update(snap Snapshot) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.jobs[snap.ID] = snap
+	return m.save()
+}
+
+// Finish marks id's job as done or failed. It's a no-op if id isn't known.
+func (m *Manager) Finish(id string, status Status, jobErr error) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	snap, ok := m.jobs[id]
+	if !ok {
+		return nil
+	}
+	snap.Status = status
+	if jobErr != nil {
+		snap.Err = jobErr.Error()
+	}
+	m.jobs[id] = snap
+	return m.save()
+}
+
+// MaxRetries caps how many times Retry will relaunch a failed job; beyond
+// that it's left Failed for good (a supervisor's "Fatal" transition)
+// rather than retried again.
+const MaxRetries = 3
+
+// Retry marks id's job StatusRetrying and bumps its Retries count, for a
+// caller that's about to relaunch the underlying operation. It refuses
+// (returning ok=false) for a job that isn't Failed, or has already hit
+// MaxRetries.
+func (m *Manager) Retry(id string) (snap Snapshot, ok bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	snap, found := m.jobs[id]
+	if !found || snap.Status != StatusFailed || snap.Retries >= MaxRetries {
+		return Snapshot{}, false
+	}
+	snap.Retries++
+	snap.Status = StatusRetrying
+	snap.Err = ""
+	m.jobs[id] = snap
+	return snap, m.save() == nil
+}
+
+// Get returns id's last reported Snapshot, if any.
+func (m *Manager) Get(id string) (Snapshot, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	snap, ok := m.jobs[id]
+	return snap, ok
+}
+
+// List returns every known job, most recently started first.
+func (m *Manager) List() []Snapshot {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	snaps := make([]Snapshot, 0, len(m.jobs))
+	for _, snap := range m.jobs {
+		snaps = append(snaps, snap)
+	}
+	sort.Slice(snaps, func(i, j int) bool {
+		return snaps[i].StartTime.After(snaps[j].StartTime)
+	})
+	return snaps
+}
+
+// save persists m.jobs. Callers must hold m.mu.
+func (m *Manager) save() error {
+	if err := os.MkdirAll(filepath.Dir(m.path), 0755); err != nil {
+		return fmt.Errorf("create job registry dir: %w", err)
+	}
+	data, err := json.MarshalIndent(m.jobs, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode job registry: %w", err)
+	}
+	return os.WriteFile(m.path, data, 0644)
+}
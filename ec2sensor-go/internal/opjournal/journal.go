@@ -0,0 +1,105 @@
+// Package opjournal persists the state of long-running deploy/upgrade
+// operations to disk, so a TUI restart (or crash) during a 60+ minute
+// deployment or an indefinitely-polling upgrade doesn't lose visibility
+// into work that's still in flight on the sensor side.
+package opjournal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Kind identifies which operation an Entry tracks.
+type Kind string
+
+const (
+	KindDeploy  Kind = "deploy"
+	KindUpgrade Kind = "upgrade"
+)
+
+// Entry is one in-flight operation's journaled state, keyed by sensor name
+// in the on-disk map.
+type Entry struct {
+	SensorName    string    `json:"sensor_name"`
+	IP            string    `json:"ip,omitempty"`
+	Kind          Kind      `json:"kind"`
+	Phase         int       `json:"phase"`
+	StartTime     time.Time `json:"start_time"`
+	TargetVersion string    `json:"target_version,omitempty"`
+	LogTail       []string  `json:"log_tail,omitempty"`
+}
+
+// Journal reads and writes the operation journal file at path.
+type Journal struct {
+	path string
+}
+
+// New builds a Journal backed by path.
+func New(path string) *Journal {
+	return &Journal{path: path}
+}
+
+// DefaultPath resolves the journal location: $XDG_CONFIG_HOME/ec2sensor/operations.json,
+// falling back to ~/.config/ec2sensor/operations.json.
+func DefaultPath() string {
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		return filepath.Join(xdg, "ec2sensor", "operations.json")
+	}
+	return filepath.Join(os.Getenv("HOME"), ".config", "ec2sensor", "operations.json")
+}
+
+// Load returns every journaled entry, keyed by sensor name. A missing file
+// is not an error - it just means no operation has ever been journaled.
+func (j *Journal) Load() (map[string]Entry, error) {
+	data, err := os.ReadFile(j.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]Entry{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read operation journal: %w", err)
+	}
+	entries := map[string]Entry{}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("parse operation journal: %w", err)
+	}
+	return entries, nil
+}
+
+// Put upserts entry, keyed by entry.SensorName, and persists the journal.
+func (j *Journal) Put(entry Entry) error {
+	entries, err := j.Load()
+	if err != nil {
+		return err
+	}
+	entries[entry.SensorName] = entry
+	return j.save(entries)
+}
+
+// Remove deletes sensorName's entry, if present, and persists the journal.
+// It's a no-op if sensorName isn't journaled.
+func (j *Journal) Remove(sensorName string) error {
+	entries, err := j.Load()
+	if err != nil {
+		return err
+	}
+	if _, ok := entries[sensorName]; !ok {
+		return nil
+	}
+	delete(entries, sensorName)
+	return j.save(entries)
+}
+
+func (j *Journal) save(entries map[string]Entry) error {
+	if err := os.MkdirAll(filepath.Dir(j.path), 0755); err != nil {
+		return fmt.Errorf("create operation journal dir: %w", err)
+	}
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode operation journal: %w", err)
+	}
+	return os.WriteFile(j.path, data, 0644)
+}
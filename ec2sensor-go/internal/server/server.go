@@ -0,0 +1,406 @@
+// Package server exposes the same sensor operations the TUI drives -
+// deploy, upgrade, enable-features, add-to-fleet, and traffic generation -
+// behind an HTTP API, so ec2sensor can run headlessly as a daemon instead
+// of (or alongside) the interactive program. Handlers call the same
+// api.SensorAPI/ssh.Client methods the TUI's Update loop does, and record
+// progress in the same jobs.Manager registry, so a job started over HTTP
+// shows up in the TUI's Background Jobs view and vice versa - the two only
+// share state through jobs.Manager's file on disk, never a process, and
+// each reads that file only when it starts.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/quanticsoul4772/ec2sensor-go/api"
+	"github.com/quanticsoul4772/ec2sensor-go/internal/audit"
+	"github.com/quanticsoul4772/ec2sensor-go/internal/jobs"
+	"github.com/quanticsoul4772/ec2sensor-go/ssh"
+)
+
+// Server wires the HTTP handlers to the clients and registries the TUI
+// itself uses. The zero value isn't usable - build one with New.
+type Server struct {
+	apiClient  api.SensorAPI
+	sshClient  *ssh.Client
+	jobManager *jobs.Manager
+	audit      *audit.Logger
+}
+
+// New builds a Server. apiClient, sshClient, jobManager, and auditLogger
+// are the same kind of instances initialModel wires up for the TUI.
+func New(apiClient api.SensorAPI, sshClient *ssh.Client, jobManager *jobs.Manager, auditLogger *audit.Logger) *Server {
+	return &Server{apiClient: apiClient, sshClient: sshClient, jobManager: jobManager, audit: auditLogger}
+}
+
+// Handler builds the mux routing /v1 endpoints to this Server's methods.
+// Go 1.21's ServeMux has no path-parameter matching, so sensor names and
+// job IDs are parsed out of r.URL.Path by hand, the same way the `bulk`
+// and `traffic run` CLI subcommands hand-parse os.Args.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/sensors", s.handleSensors)
+	mux.HandleFunc("/v1/sensors/", s.handleSensorPath)
+	mux.HandleFunc("/v1/jobs/", s.handleJob)
+	return mux
+}
+
+// handleSensors handles POST /v1/sensors (create).
+func (s *Server) handleSensors(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "only POST is supported")
+		return
+	}
+
+	name, err := s.apiClient.CreateSensor(r.Context())
+	if err != nil {
+		writeError(w, http.StatusBadGateway, fmt.Sprintf("create sensor: %v", err))
+		return
+	}
+
+	id := s.startJob(jobs.KindDeploy, name, "", func(ctx context.Context, report func(phase, message string)) error {
+		report("waiting for instance", "Waiting for sensor to be ready...")
+		deadline := time.Now().Add(5 * time.Minute)
+		for {
+			sensor, err := s.apiClient.FetchSensor(ctx, name)
+			if err == nil && sensor.IsReady() {
+				report("complete", fmt.Sprintf("Sensor ready at %s", sensor.IP))
+				return nil
+			}
+			if time.Now().After(deadline) {
+				return fmt.Errorf("sensor %s did not become ready within 5m", name)
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(5 * time.Second):
+			}
+		}
+	})
+
+	writeJSON(w, http.StatusAccepted, map[string]string{"sensor_name": name, "job_id": id})
+}
+
+// handleSensorPath dispatches the /v1/sensors/{name}[/...] routes that
+// Go 1.21's ServeMux can't express as patterns.
+func (s *Server) handleSensorPath(w http.ResponseWriter, r *http.Request) {
+	rest := strings.TrimPrefix(r.URL.Path, "/v1/sensors/")
+	parts := strings.Split(strings.Trim(rest, "/"), "/")
+	if parts[0] == "" {
+		writeError(w, http.StatusNotFound, "sensor name is required")
+		return
+	}
+	name := parts[0]
+
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodDelete:
+		s.handleDeleteSensor(w, r, name)
+	case len(parts) == 2 && parts[1] == "metrics" && r.Method == http.MethodGet:
+		s.handleMetrics(w, r, name)
+	case len(parts) == 3 && parts[1] == "features" && parts[2] == "enable" && r.Method == http.MethodPost:
+		s.handleEnableFeatures(w, r, name)
+	case len(parts) == 2 && parts[1] == "fleet" && r.Method == http.MethodPost:
+		s.handleAddToFleet(w, r, name)
+	case len(parts) == 2 && parts[1] == "upgrade" && r.Method == http.MethodPost:
+		s.handleUpgrade(w, r, name)
+	case len(parts) == 3 && parts[1] == "traffic" && parts[2] == "start" && r.Method == http.MethodPost:
+		s.handleTrafficStart(w, r, name)
+	default:
+		writeError(w, http.StatusNotFound, "no such route")
+	}
+}
+
+func (s *Server) handleDeleteSensor(w http.ResponseWriter, r *http.Request, name string) {
+	if err := s.apiClient.DeleteSensor(r.Context(), name); err != nil {
+		writeError(w, http.StatusBadGateway, fmt.Sprintf("delete sensor: %v", err))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request, name string) {
+	sensor, err := s.apiClient.FetchSensor(r.Context(), name)
+	if err != nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("fetch sensor: %v", err))
+		return
+	}
+	metrics, err := s.sshClient.CollectMetrics(sensor.IP)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, fmt.Sprintf("collect metrics: %v", err))
+		return
+	}
+	writeJSON(w, http.StatusOK, metrics)
+}
+
+func (s *Server) handleEnableFeatures(w http.ResponseWriter, r *http.Request, name string) {
+	sensor, err := s.apiClient.FetchSensor(r.Context(), name)
+	if err != nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("fetch sensor: %v", err))
+		return
+	}
+	ip := sensor.IP
+
+	id := s.startJob(jobs.KindEnableFeatures, name, ip, func(ctx context.Context, report func(phase, message string)) error {
+		report("enabling", "Running enable_sensor_features.sh...")
+		_, err := s.sshClient.EnableFeatures(ip)
+		return err
+	})
+	writeJSON(w, http.StatusAccepted, map[string]string{"job_id": id})
+}
+
+func (s *Server) handleAddToFleet(w http.ResponseWriter, r *http.Request, name string) {
+	sensor, err := s.apiClient.FetchSensor(r.Context(), name)
+	if err != nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("fetch sensor: %v", err))
+		return
+	}
+	ip := sensor.IP
+
+	id := s.startJob(jobs.KindAddToFleet, name, ip, func(ctx context.Context, report func(phase, message string)) error {
+		report("adding", "Adding to Fleet Manager...")
+		_, err := s.sshClient.AddToFleetManager(ip)
+		return err
+	})
+	writeJSON(w, http.StatusAccepted, map[string]string{"job_id": id})
+}
+
+// upgradeRequest is the body POST /v1/sensors/{name}/upgrade expects. An
+// empty Version upgrades to the latest release on the sensor's current
+// channel; a non-empty Version pins to that release on Channel.
+type upgradeRequest struct {
+	Channel string `json:"channel"`
+	Version string `json:"version"`
+}
+
+func (s *Server) handleUpgrade(w http.ResponseWriter, r *http.Request, name string) {
+	var req upgradeRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	sensor, err := s.apiClient.FetchSensor(r.Context(), name)
+	if err != nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("fetch sensor: %v", err))
+		return
+	}
+	ip := sensor.IP
+
+	id := s.startJob(jobs.KindUpgrade, name, ip, func(ctx context.Context, report func(phase, message string)) error {
+		if req.Version != "" {
+			report("upgrading", fmt.Sprintf("Upgrading to %s on %s...", req.Version, req.Channel))
+			return s.sshClient.RunUpgradeSpecific(ip, req.Channel, req.Version)
+		}
+		report("upgrading", "Reading admin password...")
+		adminPassword, err := s.sshClient.GetAdminPassword(ip)
+		if err != nil {
+			return fmt.Errorf("get admin password: %w", err)
+		}
+		report("upgrading", "Upgrading to latest...")
+		return s.sshClient.RunUpgradeLatest(ip, adminPassword)
+	})
+	writeJSON(w, http.StatusAccepted, map[string]string{"job_id": id})
+}
+
+// trafficStartRequest mirrors the fields renderTrafficStart collects
+// interactively: target, port, protocol, packets-per-second, duration.
+type trafficStartRequest struct {
+	TargetIP   string `json:"target_ip"`
+	TargetPort string `json:"target_port"`
+	Protocol   string `json:"protocol"`
+	PPS        string `json:"pps"`
+	Duration   string `json:"duration"`
+}
+
+func (s *Server) handleTrafficStart(w http.ResponseWriter, r *http.Request, name string) {
+	var req trafficStartRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if req.TargetIP == "" {
+		writeError(w, http.StatusBadRequest, "target_ip is required")
+		return
+	}
+	if req.TargetPort == "" {
+		req.TargetPort = "5555"
+	}
+	if req.Protocol == "" {
+		req.Protocol = "udp"
+	}
+	if req.PPS == "" {
+		req.PPS = "1000"
+	}
+	if req.Duration == "" {
+		req.Duration = "0"
+	}
+
+	sensor, err := s.apiClient.FetchSensor(r.Context(), name)
+	if err != nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("fetch sensor: %v", err))
+		return
+	}
+	ip := sensor.IP
+
+	id := s.startJob(jobs.KindTraffic, name, ip, func(ctx context.Context, report func(phase, message string)) error {
+		report("starting", fmt.Sprintf("Starting traffic generation to %s:%s...", req.TargetIP, req.TargetPort))
+		return s.sshClient.StartTrafficGeneration(ip, req.TargetIP, req.TargetPort, req.Protocol, req.PPS, req.Duration)
+	})
+	writeJSON(w, http.StatusAccepted, map[string]string{"job_id": id})
+}
+
+// handleJob handles GET /v1/jobs/{id}. With Accept: text/event-stream (or
+// ?stream=1) it streams the job's log tail as Server-Sent Events until the
+// job reaches a terminal status; otherwise it returns the current
+// jobs.Snapshot as a single JSON document.
+func (s *Server) handleJob(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "only GET is supported")
+		return
+	}
+
+	id := strings.TrimPrefix(r.URL.Path, "/v1/jobs/")
+	if id == "" {
+		writeError(w, http.StatusNotFound, "job id is required")
+		return
+	}
+
+	if r.Header.Get("Accept") == "text/event-stream" || r.URL.Query().Get("stream") == "1" {
+		s.streamJob(w, r, id)
+		return
+	}
+
+	snap, ok := s.jobManager.Get(id)
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("no such job %q", id))
+		return
+	}
+	writeJSON(w, http.StatusOK, snap)
+}
+
+// streamJob polls the job manager and writes each new log line as an SSE
+// "data:" event, closing once the job reaches StatusDone or StatusFailed
+// or the client disconnects.
+func (s *Server) streamJob(w http.ResponseWriter, r *http.Request, id string) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "streaming unsupported")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	sent := 0
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		snap, ok := s.jobManager.Get(id)
+		if !ok {
+			fmt.Fprintf(w, "event: error\ndata: no such job %q\n\n", id)
+			flusher.Flush()
+			return
+		}
+		for ; sent < len(snap.LogTail); sent++ {
+			fmt.Fprintf(w, "data: %s\n\n", snap.LogTail[sent])
+		}
+		if snap.Status == jobs.StatusDone || snap.Status == jobs.StatusFailed {
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", snap.Status, snap.Err)
+			flusher.Flush()
+			return
+		}
+		flusher.Flush()
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// startJob registers a Running Snapshot under jobs.ID(kind, sensorName),
+// runs work in the background, and returns that ID for the caller to poll
+// or stream via GET /v1/jobs/{id}. work's report callback both appends to
+// the audit trail (so RotatingFileSink/WebhookSink/etc. see it) and
+// refreshes the Snapshot's log tail so a streaming client sees it live.
+func (s *Server) startJob(kind jobs.Kind, sensorName, sensorIP string, work func(ctx context.Context, report func(phase, message string)) error) string {
+	id := jobs.ID(kind, sensorName)
+	start := time.Now()
+	s.jobManager.Update(jobs.Snapshot{
+		ID:         id,
+		SensorName: sensorName,
+		SensorIP:   sensorIP,
+		Kind:       kind,
+		Status:     jobs.StatusRunning,
+		StartTime:  start,
+	})
+
+	report := func(phase, message string) {
+		s.audit.Emit(context.Background(), audit.Event{
+			SensorID:  sensorName,
+			SensorIP:  sensorIP,
+			Operation: string(kind),
+			Phase:     phase,
+			Level:     audit.LevelInfo,
+			Message:   message,
+		})
+		s.jobManager.Update(jobs.Snapshot{
+			ID:         id,
+			SensorName: sensorName,
+			SensorIP:   sensorIP,
+			Kind:       kind,
+			Status:     jobs.StatusRunning,
+			StartTime:  start,
+			Phase:      phase,
+			LogTail:    s.audit.Tail(string(kind), sensorName),
+		})
+	}
+
+	go func() {
+		err := work(context.Background(), report)
+		if err != nil {
+			s.audit.Emit(context.Background(), audit.Event{
+				SensorID:  sensorName,
+				SensorIP:  sensorIP,
+				Operation: string(kind),
+				Level:     audit.LevelError,
+				Message:   err.Error(),
+				Err:       err.Error(),
+			})
+			s.jobManager.Finish(id, jobs.StatusFailed, err)
+			return
+		}
+		s.jobManager.Finish(id, jobs.StatusDone, nil)
+	}()
+
+	return id
+}
+
+func decodeJSON(r *http.Request, dst any) error {
+	if r.Body == nil || r.ContentLength == 0 {
+		return nil
+	}
+	dec := json.NewDecoder(r.Body)
+	if err := dec.Decode(dst); err != nil {
+		return fmt.Errorf("decode request body: %w", err)
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
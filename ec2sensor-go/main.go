@@ -2,20 +2,35 @@ package main
 
 import (
 	"bufio"
+	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"os/exec"
+	"os/signal"
+	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/quanticsoul4772/ec2sensor-go/api"
 	"github.com/quanticsoul4772/ec2sensor-go/config"
+	"github.com/quanticsoul4772/ec2sensor-go/internal/audit"
+	"github.com/quanticsoul4772/ec2sensor-go/internal/batch"
+	"github.com/quanticsoul4772/ec2sensor-go/internal/deploy"
+	"github.com/quanticsoul4772/ec2sensor-go/internal/jobs"
+	"github.com/quanticsoul4772/ec2sensor-go/internal/opjournal"
+	"github.com/quanticsoul4772/ec2sensor-go/internal/server"
+	"github.com/quanticsoul4772/ec2sensor-go/metrics"
 	"github.com/quanticsoul4772/ec2sensor-go/models"
 	"github.com/quanticsoul4772/ec2sensor-go/ssh"
+	"github.com/quanticsoul4772/ec2sensor-go/store"
+	"github.com/quanticsoul4772/ec2sensor-go/telemetry"
+	"github.com/quanticsoul4772/ec2sensor-go/traffic"
 	"github.com/quanticsoul4772/ec2sensor-go/ui"
 )
 
@@ -38,7 +53,15 @@ const (
 	ViewFleetManager
 	ViewAddingToFleet
 	ViewTrafficGenerator
+	ViewTrafficProfile
 	ViewTrafficStart
+	ViewTrafficGenProfile
+	ViewTrafficGenProfileNew
+	ViewConfirmBatch
+	ViewBatchOperations
+	ViewTrafficScenario
+	ViewTrafficScenarioReport
+	ViewBackgroundJobs
 )
 
 // Model is the main application state
@@ -47,37 +70,56 @@ type Model struct {
 	config *config.Config
 
 	// Clients
-	apiClient *api.Client
-	sshClient *ssh.Client
+	apiClient   api.SensorAPI
+	sshClient   *ssh.Client
+	sensorStore store.SensorStore
+	// metrics is nil unless cfg.MetricsEnabled, in which case sensor
+	// gauges are refreshed on every sensorsLoadedMsg.
+	metrics *metrics.Metrics
+
+	// ctx is passed to every apiClient call so in-flight requests are
+	// cancelled when the TUI quits; cancel tears it down.
+	ctx    context.Context
+	cancel context.CancelFunc
 
 	// UI state
-	view          View
-	previousView  View
-	cursor        int
-	multiSelect   bool
-	themeName     string
-	styles        ui.Styles
-	width, height int
+	view           View
+	previousView   View
+	cursor         int
+	multiSelect    bool
+	themeName      string
+	stylesetName   string
+	stylesetLoader *ui.StylesetLoader
+	capabilities   ui.Capabilities
+	keymap         *ui.Keymap
+	pendingKey     string
+	styles         ui.Styles
+	width, height  int
 
 	// Data
-	sensors       []*models.Sensor
-	selectedIdx   int
-	runningCount  int
-	errorCount    int
+	sensors      []*models.Sensor
+	selectedIdx  int
+	runningCount int
+	errorCount   int
 
 	// Session tracking
 	sessionStart time.Time
 	lastRefresh  time.Time
 
 	// Loading state
-	loading       bool
-	loadingMsg    string
-	spinner       spinner.Model
+	loading    bool
+	loadingMsg string
+	spinner    spinner.Model
 
 	// Messages/errors
 	statusMessage string
 	errorMessage  string
 
+	// refreshPaused freezes the live-refreshing dashboard loop (ViewHealth
+	// and the in-flight operation views) so the screen stops redrawing
+	// under the user - e.g. while they're copying text. Toggled with "p".
+	refreshPaused bool
+
 	// API status
 	apiOnline bool
 
@@ -87,40 +129,125 @@ type Model struct {
 	deployStartTime     time.Time
 	deployStatus        string
 	deployLogs          []string
-	deployPhase         int    // 1=SSH port, 2=SSH service, 3=Seeding
+	deployPhase         deploy.Phase
 	deployPhaseStart    time.Time
+	deployPhaseEvents   <-chan deploy.PhaseChangedEvent
 
 	// Upgrade state
-	upgrading           bool
-	upgradeStartTime    time.Time
-	upgradeLogs         []string
-	upgradeCurrentVersion string
-	upgradeTargetVersion  string
+	upgrading                bool
+	upgradingSensorName      string
+	upgradingSensorIP        string // polled by the tickMsg handler, so ESC-ing away and changing m.selectedIdx doesn't redirect progress checks at a different sensor
+	upgradeStartTime         time.Time
+	upgradeLogs              []string
+	upgradeCurrentVersion    string
+	upgradeTargetVersion     string
 	upgradeAvailableVersions []string
-	upgradeOption       int // 1 = latest, 2 = specific
-	upgradeReleaseChannel string
-	upgradeAdminPassword  string
+	upgradeOption            int // 1 = latest, 2 = specific
+	upgradeReleaseChannel    string
+	upgradeAdminPassword     string
 
 	// Enable Features state
-	enablingFeatures    bool
-	enableFeaturesStart time.Time
-	enableFeaturesLogs  []string
+	enablingFeatures         bool
+	enableFeaturesSensorName string
+	enableFeaturesStart      time.Time
+	enableFeaturesLogs       []string
 
 	// Fleet Manager state
-	addingToFleet       bool
-	fleetStart          time.Time
-	fleetLogs           []string
+	addingToFleet   bool
+	fleetSensorName string
+	fleetStart      time.Time
+	fleetLogs       []string
 
 	// Delete state
 	deletingSensorName string
 
-	// Traffic Generator state
-	trafficTargetIP     string
-	trafficTargetPort   string
-	trafficProtocol     string
-	trafficPPS          string
-	trafficDuration     string
-	trafficInputStep    int // 0=IP, 1=port, 2=protocol, 3=pps, 4=duration
+	// Batch operation state - fans an operation ("upgrade", "delete",
+	// "enable features" or "add to fleet") out across every Selected sensor via internal/batch,
+	// instead of acting on just selectedIdx.
+	batchOp      string
+	batchSensors []*models.Sensor
+	batchOrder   []string // sensor names, for a stable progress table order
+	batchStates  map[string]*batchSensorState
+	batchEvents  <-chan batch.Event
+	batchActive  bool
+	batchCancel  context.CancelFunc
+
+	// Traffic Generator state. A profile is picked first (ViewTrafficProfile,
+	// cursor trafficProfileIdx into trafficProfiles), then ViewTrafficStart
+	// prompts for only that profile's declared Params, keyed into
+	// trafficParamValues by ParamSpec.Name - replacing the old fixed
+	// IP/port/protocol/pps/duration set every profile used to share.
+	trafficProfiles    []traffic.ProfileSpec
+	trafficProfileIdx  int
+	trafficProfile     traffic.ProfileSpec
+	trafficParamValues map[string]string
+	trafficParamStep   int
+
+	// Saved generator profiles (ViewTrafficGenProfile, cursor
+	// trafficGenProfileIdx into trafficGenProfiles loaded from
+	// traffic.GeneratorProfilesPath) jump straight to StartProfile with
+	// their saved target/pattern/rate instead of re-entering them.
+	// ViewTrafficGenProfileNew reuses trafficParamValues/trafficParamStep
+	// above to collect traffic.QuickProfileParams for a new one.
+	trafficGenProfiles   []traffic.GeneratorProfile
+	trafficGenProfileIdx int
+
+	// Traffic scenario state - a scripted multi-stage run picked from
+	// traffic.ListScenarios(), as opposed to the one-shot config above.
+	trafficScenarios      []string
+	trafficScenarioIdx    int
+	trafficScenarioReport *traffic.Report
+	trafficScenarioSensor string
+	trafficScenarioStart  time.Time
+
+	// Operation journal - persists deploy/upgrade progress to disk so a TUI
+	// restart mid-operation doesn't lose visibility into work still running
+	// on the sensor. resumableOps is the journal snapshot read at startup;
+	// journalReconciled guards the one-time auto-restore against
+	// sensorsLoadedMsg firing again on every later refresh.
+	journal           *opjournal.Journal
+	resumableOps      map[string]opjournal.Entry
+	journalReconciled bool
+
+	// jobManager registers every deploy/upgrade/enable-features/add-to-fleet/
+	// traffic-scenario run so its phase and log tail survive the user
+	// pressing ESC or navigating elsewhere - the operation itself was never
+	// tied to m.view, only its visibility was. jobsCursor is the selection
+	// in ViewBackgroundJobs.
+	jobManager   *jobs.Manager
+	jobsCursor   int
+	jobsDetailID string // non-empty selects one job's detail pane over the list
+
+	// audit emits a structured, durable record of every operation's
+	// start/step/completion to the sink selected by cfg.AuditSinkKind, and
+	// backs the *Logs slices (deployLogs, upgradeLogs, ...) rendered by
+	// progress views - they're read from audit.Tail rather than owned
+	// independently, so the trail survives past whatever's on screen.
+	audit *audit.Logger
+
+	// watcherEvents streams incremental api.Watcher diffs (added/changed/
+	// deleted sensors); sensorEventMsg mutates m.sensors in place from
+	// these instead of replacing the slice, so cursor position, expanded
+	// metrics and Selected flags survive a refresh. The 60s full resync
+	// in the tickMsg handler remains as a fallback.
+	watcherEvents <-chan api.Event
+
+	// configWatcher reloads config.toml on every change; configEvents
+	// streams the results. pendingConfigReload holds a reload that arrived
+	// while m.deploying || m.upgrading, applied once that operation
+	// finishes instead of swapping m.apiClient/m.sshClient mid-poll.
+	configWatcher       *config.Watcher
+	configEvents        <-chan config.ReloadResult
+	pendingConfigReload *config.Config
+}
+
+// batchSensorState tracks one sensor's row in the batch progress table,
+// updated as its batch.Event stream arrives.
+type batchSensorState struct {
+	phase   batch.Phase
+	message string
+	started time.Time
+	err     error
 }
 
 // Messages
@@ -136,10 +263,46 @@ type (
 		err       error
 	}
 
-	tickMsg       time.Time
-	apiStatusMsg  bool
-	deleteResult  struct{ err error }
-	sshConnectMsg struct{ ip string }
+	tickMsg time.Time
+	// refreshSensorMsg drives the live-refreshing dashboard loop: dispatched
+	// every m.config.DashboardRefreshInterval while ViewHealth or one of the
+	// in-flight operation views is active, it re-polls and re-renders in
+	// place. The loop dies on its own once the view moves on or the refresh
+	// is paused - see the refreshSensorMsg case in Update.
+	refreshSensorMsg time.Time
+	apiStatusMsg     bool
+	deleteResult     struct{ err error }
+	sshConnectMsg    struct{ ip string }
+
+	// batchEventMsg carries the next batch.Event off m.batchEvents; ok is
+	// false once the channel has closed (every job reached a terminal
+	// event).
+	batchEventMsg struct {
+		event batch.Event
+		ok    bool
+	}
+
+	// sensorEventMsg carries the next api.Event off m.watcherEvents; ok is
+	// false once the watcher's context is cancelled and the channel closes.
+	sensorEventMsg struct {
+		event api.Event
+		ok    bool
+	}
+
+	// trafficScenarioDoneMsg carries the finished *traffic.Report once a
+	// scenario run (started in ViewTrafficScenario) completes.
+	trafficScenarioDoneMsg struct {
+		report *traffic.Report
+		err    error
+	}
+
+	// configReloadedMsg carries the next config.ReloadResult off
+	// m.configEvents; ok is false once the watcher is closed and the
+	// channel closes.
+	configReloadedMsg struct {
+		result config.ReloadResult
+		ok     bool
+	}
 
 	// Deployment messages
 	deployStartedMsg struct {
@@ -163,6 +326,16 @@ type (
 		err        error
 	}
 
+	// phaseChangedMsg carries the next deploy.PhaseChangedEvent off
+	// m.deployPhaseEvents; ok is false once the channel is drained for
+	// good (a new deploy replaces it with a fresh channel). Update
+	// re-issues waitForPhaseChangedEvent after every event to keep
+	// draining it.
+	phaseChangedMsg struct {
+		event deploy.PhaseChangedEvent
+		ok    bool
+	}
+
 	// Upgrade messages
 	upgradeInfoMsg struct {
 		currentVersion    string
@@ -177,10 +350,10 @@ type (
 	}
 
 	upgradeProgressMsg struct {
-		sshAvailable    bool
-		processRunning  bool
-		newVersion      string
-		err             error
+		sshAvailable   bool
+		processRunning bool
+		newVersion     string
+		err            error
 	}
 
 	upgradeCompleteMsg struct {
@@ -194,6 +367,15 @@ type (
 		err    error
 	}
 
+	// jobRetryResultMsg carries the result of retryJob re-running a failed
+	// enable-features or add-to-fleet job from the Background Jobs view.
+	// Unlike the original operation, a retry doesn't drive a foreground
+	// view - it just updates the job registry in place.
+	jobRetryResultMsg struct {
+		id  string
+		err error
+	}
+
 	// Fleet Manager messages
 	fleetResultMsg struct {
 		output string
@@ -201,25 +383,189 @@ type (
 	}
 )
 
+// stylesetLoaderFor returns a StylesetLoader rooted at cfg.StylesetPath when
+// set, otherwise the default ~/.ec2sensor/stylesets directory.
+func stylesetLoaderFor(cfg *config.Config) *ui.StylesetLoader {
+	loader := ui.NewStylesetLoader()
+	if cfg.StylesetPath != "" {
+		loader.Dir = cfg.StylesetPath
+	}
+	return loader
+}
+
+// storeFor builds the store.SensorStore selected by cfg.StoreBackend,
+// falling back to an in-memory store (and reporting why) if the requested
+// backend can't be opened.
+func storeFor(cfg *config.Config) (store.SensorStore, error) {
+	switch cfg.StoreBackend {
+	case "", "memory":
+		return store.NewMemoryStore(), nil
+	case "bolt":
+		return store.NewBoltStore(cfg.StorePath)
+	case "redis":
+		return store.NewRedisStore(store.RedisConfig{
+			Addr:        cfg.RedisAddr,
+			Password:    cfg.RedisPassword,
+			DB:          cfg.RedisDB,
+			MaxIdle:     cfg.RedisMaxIdle,
+			MaxActive:   cfg.RedisMaxActive,
+			IdleTimeout: cfg.RedisIdleTimeout,
+			DialTimeout: cfg.RedisDialTimeout,
+		}), nil
+	default:
+		return nil, fmt.Errorf("unknown store backend %q", cfg.StoreBackend)
+	}
+}
+
+// auditSinkFor builds the audit.Sink selected by cfg.AuditSinkKind. "none"
+// (or an unset AuditSinkKind being left as the default "file") is handled
+// by the caller wrapping a possibly-nil sink in audit.NewLogger, which
+// keeps the in-memory tail going even with nowhere durable to write.
+func auditSinkFor(cfg *config.Config) (audit.Sink, error) {
+	switch cfg.AuditSinkKind {
+	case "", "file":
+		return audit.NewRotatingFileSink(cfg.AuditPath, cfg.AuditMaxSizeBytes, cfg.AuditMaxAge, cfg.AuditMaxBackups), nil
+	case "jsonl":
+		return audit.NewJSONLSink(cfg.AuditPath), nil
+	case "syslog":
+		return audit.NewSyslogSink()
+	case "webhook":
+		return audit.NewWebhookSink(cfg.AuditWebhookURL, cfg.AuditWebhookToken), nil
+	case "none":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown audit sink kind %q", cfg.AuditSinkKind)
+	}
+}
+
 func initialModel(cfg *config.Config) Model {
 	s := spinner.New()
 	s.Spinner = spinner.Dot
 
+	caps := ui.DetectCapabilities()
 	theme := ui.GetTheme(cfg.Theme)
+	if !cfg.ThemeExplicit {
+		theme = caps.AutoTheme()
+	}
+
+	km, err := ui.LoadKeymap(cfg.Keys)
+	errorMessage := ""
+	if err != nil {
+		km = ui.DefaultKeymap()
+		errorMessage = "keymap: " + err.Error()
+	}
+
+	sensorStore, err := storeFor(cfg)
+	if err != nil {
+		sensorStore = store.NewMemoryStore()
+		if errorMessage != "" {
+			errorMessage += "; "
+		}
+		errorMessage += fmt.Sprintf("store: %v, falling back to memory", err)
+	}
+	client := api.NewClient(cfg)
+
+	sshClient := ssh.NewClient(cfg)
+
+	var m *metrics.Metrics
+	if cfg.MetricsEnabled {
+		m = metrics.New()
+		if cfg.PprofEnabled {
+			m.EnablePprof()
+		}
+		client.SetMetrics(m)
+		sshClient.SetMetrics(m)
+		sshClient.WithSinks(ssh.NewPrometheusMetricsSink(m))
+		go func() {
+			if err := m.ListenAndServe(cfg.MetricsAddr); err != nil {
+				fmt.Fprintf(os.Stderr, "metrics server: %v\n", err)
+			}
+		}()
+	}
+
+	if cfg.StatsdEnabled {
+		if sink, err := ssh.NewStatsdSink(cfg.StatsdHost, cfg.StatsdPort); err != nil {
+			fmt.Fprintf(os.Stderr, "statsd sink: %v\n", err)
+		} else {
+			sshClient.WithSinks(sink)
+		}
+	}
+
+	apiClient := api.NewCachingClient(client, sensorStore, cfg.CacheTTL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	journal := opjournal.New(opjournal.DefaultPath())
+	resumableOps, err := journal.Load()
+	if err != nil {
+		resumableOps = map[string]opjournal.Entry{}
+		if errorMessage != "" {
+			errorMessage += "; "
+		}
+		errorMessage += fmt.Sprintf("operation journal: %v", err)
+	}
+
+	jobManager := jobs.NewManager(jobs.DefaultPath())
+	if err := jobManager.Load(); err != nil {
+		if errorMessage != "" {
+			errorMessage += "; "
+		}
+		errorMessage += fmt.Sprintf("job registry: %v", err)
+	}
+
+	auditSink, err := auditSinkFor(cfg)
+	if err != nil {
+		if errorMessage != "" {
+			errorMessage += "; "
+		}
+		errorMessage += fmt.Sprintf("audit sink: %v, events won't be persisted", err)
+	}
+	auditLogger := audit.NewLogger(auditSink, 20)
+
+	watcher := api.NewWatcher(apiClient, func() []string {
+		names, _ := readSensorsFile(cfg.SensorsFile)
+		return names
+	}, 10*time.Second)
+
+	var configEvents <-chan config.ReloadResult
+	configWatcher, err := config.WatchFile(cfg.Path())
+	if err != nil {
+		if errorMessage != "" {
+			errorMessage += "; "
+		}
+		errorMessage += fmt.Sprintf("config watcher: %v", err)
+	} else {
+		configEvents = configWatcher.Events()
+	}
 
 	return Model{
-		config:       cfg,
-		apiClient:    api.NewClient(cfg),
-		sshClient:    ssh.NewClient(cfg),
-		view:         ViewHome,
-		themeName:    cfg.Theme,
-		styles:       ui.NewStyles(theme),
-		sessionStart: time.Now(),
-		lastRefresh:  time.Now(),
-		spinner:      s,
-		loading:      true,
-		loadingMsg:   "Loading sensors...",
-		apiOnline:    true,
+		config:         cfg,
+		apiClient:      apiClient,
+		sshClient:      sshClient,
+		sensorStore:    sensorStore,
+		metrics:        m,
+		ctx:            ctx,
+		cancel:         cancel,
+		view:           ViewHome,
+		themeName:      theme.Name,
+		stylesetLoader: stylesetLoaderFor(cfg),
+		capabilities:   caps,
+		keymap:         km,
+		styles:         ui.NewStyles(theme, caps),
+		sessionStart:   time.Now(),
+		lastRefresh:    time.Now(),
+		spinner:        s,
+		loading:        true,
+		loadingMsg:     "Loading sensors...",
+		apiOnline:      true,
+		errorMessage:   errorMessage,
+		journal:        journal,
+		resumableOps:   resumableOps,
+		jobManager:     jobManager,
+		audit:          auditLogger,
+		watcherEvents:  watcher.Run(ctx),
+		configWatcher:  configWatcher,
+		configEvents:   configEvents,
 	}
 }
 
@@ -228,6 +574,8 @@ func (m Model) Init() tea.Cmd {
 		m.loadSensors(),
 		m.spinner.Tick,
 		tickCmd(),
+		m.waitForSensorEvent(),
+		m.waitForConfigReload(),
 	)
 }
 
@@ -244,17 +592,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.handleKeyPress(msg)
 
 	case spinner.TickMsg:
-		if m.loading || m.deploying || m.upgrading || m.enablingFeatures || m.addingToFleet {
+		if m.loading || m.deploying || m.upgrading || m.enablingFeatures || m.addingToFleet || m.batchActive {
 			var cmd tea.Cmd
 			m.spinner, cmd = m.spinner.Update(msg)
 			cmds = append(cmds, cmd)
 		}
 
 	case tickMsg:
-		// If upgrading, check progress
-		if m.upgrading && m.selectedIdx < len(m.sensors) {
-			ip := m.sensors[m.selectedIdx].IP
-			cmds = append(cmds, m.checkUpgradeProgress(ip))
+		// If upgrading, check progress. This keys off m.upgradingSensorIP,
+		// not m.selectedIdx, so the poll keeps tracking the right sensor
+		// even after the user ESCs away and selects something else.
+		if m.upgrading && m.upgradingSensorIP != "" {
+			cmds = append(cmds, m.checkUpgradeProgress(m.upgradingSensorIP))
 		} else if m.deploying && m.deployingSensorName != "" {
 			// If deploying, check status
 			cmds = append(cmds, m.checkDeployStatus())
@@ -266,6 +615,32 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmds = append(cmds, tickCmd())
 		}
 
+	case refreshSensorMsg:
+		// Paused, or the view moved on since this tick was scheduled - let
+		// the loop die rather than ticking somewhere it no longer applies.
+		// Pressing "p" to resume (or re-entering the view) starts a fresh
+		// one.
+		if m.refreshPaused {
+			break
+		}
+		switch m.view {
+		case ViewHealth:
+			if m.selectedIdx < len(m.sensors) {
+				sensor := m.sensors[m.selectedIdx]
+				if sensor.IsReady() {
+					cmds = append(cmds, m.collectMetrics(m.selectedIdx, sensor.IP))
+				}
+			}
+			cmds = append(cmds, refreshTickCmd(m.config.DashboardRefreshInterval))
+		case ViewDeploying, ViewUpgrading, ViewEnablingFeatures, ViewAddingToFleet:
+			// These views already drive their own phase-probe polling
+			// (checkDeployStatus/checkUpgradeProgress/the enable-features
+			// and add-to-fleet result messages); this loop just keeps them
+			// re-rendering in place and stays alive for as long as the view
+			// does.
+			cmds = append(cmds, refreshTickCmd(m.config.DashboardRefreshInterval))
+		}
+
 	case sensorsLoadedMsg:
 		// Only clear loading if we're not in the middle of a delete operation
 		if m.deletingSensorName == "" {
@@ -277,18 +652,97 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.sensors = msg.sensors
 			m.lastRefresh = time.Now()
 			m.countSensors()
+			if m.metrics != nil {
+				m.metrics.SetSensors(m.sensors)
+			}
 			// Start collecting metrics for running sensors
 			for i, sensor := range m.sensors {
 				if sensor.IsReady() {
 					cmds = append(cmds, m.collectMetrics(i, sensor.IP))
 				}
 			}
+
+			// One-time reconciliation against the operation journal: for
+			// each journaled entry whose sensor still exists, resume
+			// monitoring it; entries for sensors that no longer exist are
+			// stale, so drop them. Only one operation can be actively
+			// monitored at a time - any remaining entries stay in
+			// m.resumableOps for manual attach from the operations menu.
+			if !m.journalReconciled {
+				m.journalReconciled = true
+				for name, entry := range m.resumableOps {
+					idx := -1
+					for i, sensor := range m.sensors {
+						if sensor.Name == name {
+							idx = i
+							break
+						}
+					}
+					if idx < 0 {
+						m.journal.Remove(name)
+						delete(m.resumableOps, name)
+						continue
+					}
+					if !m.deploying && !m.upgrading {
+						var cmd tea.Cmd
+						m, cmd = m.attachToOperation(idx, entry)
+						cmds = append(cmds, cmd)
+					}
+				}
+			}
+		}
+
+	case sensorEventMsg:
+		if !msg.ok {
+			return m, nil
+		}
+		idx := m.applySensorEvent(msg.event)
+		m.countSensors()
+		if m.metrics != nil {
+			m.metrics.SetSensors(m.sensors)
+		}
+		if idx >= 0 && msg.event.Kind != api.SensorDeleted && m.sensors[idx].IsReady() {
+			cmds = append(cmds, m.collectMetrics(idx, m.sensors[idx].IP))
+		}
+		cmds = append(cmds, m.waitForSensorEvent())
+
+	case configReloadedMsg:
+		if !msg.ok {
+			return m, nil
+		}
+		if msg.result.Err != nil {
+			m.errorMessage = fmt.Sprintf("config reload: %v", msg.result.Err)
+		} else if m.deploying || m.upgrading {
+			m.pendingConfigReload = msg.result.Config
+			m.statusMessage = "Config changed on disk; applying once the current operation finishes."
+		} else {
+			m = m.applyConfigReload(msg.result.Config)
 		}
+		cmds = append(cmds, m.waitForConfigReload())
+
+	case trafficScenarioDoneMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.errorMessage = fmt.Sprintf("Scenario failed: %v", msg.err)
+			m.view = ViewOperations
+			m.jobManager.Finish(jobs.ID(jobs.KindTraffic, m.trafficScenarioSensor), jobs.StatusFailed, msg.err)
+			return m, nil
+		}
+		m.trafficScenarioReport = msg.report
+		m.view = ViewTrafficScenarioReport
+		trafficStatus := jobs.StatusDone
+		if !msg.report.Passed {
+			trafficStatus = jobs.StatusFailed
+		}
+		m.jobManager.Finish(jobs.ID(jobs.KindTraffic, m.trafficScenarioSensor), trafficStatus, nil)
 
 	case metricsLoadedMsg:
 		if msg.err == nil && msg.sensorIdx < len(m.sensors) {
 			m.sensors[msg.sensorIdx].Metrics = msg.metrics
 			m.sensors[msg.sensorIdx].MetricsUpdated = time.Now()
+			if m.metrics != nil {
+				m.metrics.SetSensors(m.sensors)
+			}
 		}
 
 	case apiStatusMsg:
@@ -313,8 +767,29 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.errorMessage = fmt.Sprintf("Failed to create sensor: %v", msg.err)
 		} else {
 			m.deployingSensorName = msg.sensorName
-			m.deployLogs = append(m.deployLogs, fmt.Sprintf("✓ Sensor created: %s", shortenSensorName(msg.sensorName)))
-			m.deployLogs = append(m.deployLogs, "Waiting for sensor to be ready (~20 minutes)...")
+			// m.deployLogs so far (just "Creating new sensor...") was
+			// written before the sensor's name was known, so it couldn't be
+			// keyed into the audit trail yet - seed it now under the real
+			// name before appending further.
+			m.audit.Seed("deploy", m.deployingSensorName, m.deployLogs)
+			m.deployLogs = m.logEvent("deploy", m.deployingSensorName, "", audit.LevelInfo, fmt.Sprintf("✓ Sensor created: %s", shortenSensorName(msg.sensorName)))
+			m.deployLogs = m.logEvent("deploy", m.deployingSensorName, "", audit.LevelInfo, "Waiting for sensor to be ready (~20 minutes)...")
+			m.journal.Put(opjournal.Entry{
+				SensorName: m.deployingSensorName,
+				Kind:       opjournal.KindDeploy,
+				Phase:      int(m.deployPhase),
+				StartTime:  m.deployStartTime,
+				LogTail:    m.deployLogs,
+			})
+			m.jobManager.Update(jobs.Snapshot{
+				ID:         jobs.ID(jobs.KindDeploy, m.deployingSensorName),
+				SensorName: m.deployingSensorName,
+				Kind:       jobs.KindDeploy,
+				Status:     jobs.StatusRunning,
+				StartTime:  m.deployStartTime,
+				Phase:      "Waiting for sensor to be ready",
+				LogTail:    m.deployLogs,
+			})
 			// Start polling for status
 			cmds = append(cmds, m.checkDeployStatus())
 		}
@@ -325,15 +800,31 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		if msg.err != nil {
 			// Log error but continue monitoring
-			m.deployLogs = append(m.deployLogs, fmt.Sprintf("[%s] ⚠ %v", formatElapsed(elapsed), msg.err))
+			m.deployLogs = m.logEvent("deploy", m.deployingSensorName, "", audit.LevelWarn, fmt.Sprintf("[%s] ⚠ %v", formatElapsed(elapsed), msg.err))
 			cmds = append(cmds, tea.Tick(15*time.Second, func(t time.Time) tea.Msg {
 				return tickMsg(t)
 			}))
 		} else {
-			// Update phase tracking
-			if msg.phase > m.deployPhase {
-				m.deployPhase = msg.phase
-				m.deployPhaseStart = time.Now()
+			// Update phase tracking - run the observed phase through
+			// deploy.Transition rather than just taking whichever phase
+			// number is bigger, so an out-of-order probe result can't
+			// silently skip a step.
+			if target := deploy.Phase(msg.phase); target > m.deployPhase {
+				if event, ok := deployAdvanceEvent(target); ok {
+					if next, err := deploy.Transition(m.deployPhase, event); err == nil {
+						from := m.deployPhase
+						m.deployPhase = next
+						m.deployPhaseStart = time.Now()
+						if m.deployPhaseEvents != nil {
+							select {
+							case m.deployPhaseEvents <- deploy.PhaseChangedEvent{From: from, To: next, At: time.Now(), Detail: msg.status}:
+							default:
+							}
+						}
+					} else {
+						m.deployLogs = m.logEvent("deploy", m.deployingSensorName, "", audit.LevelWarn, fmt.Sprintf("[%s] ⚠ %v", formatElapsed(elapsed), err))
+					}
+				}
 			}
 
 			// Build status based on phase
@@ -341,7 +832,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case 0:
 				// Still waiting for API to report running
 				m.deployStatus = msg.status
-				m.deployLogs = append(m.deployLogs, fmt.Sprintf("[%s] API Status: %s", formatElapsed(elapsed), msg.status))
+				m.deployLogs = m.logEvent("deploy", m.deployingSensorName, "", audit.LevelInfo, fmt.Sprintf("[%s] API Status: %s", formatElapsed(elapsed), msg.status))
 				cmds = append(cmds, tea.Tick(30*time.Second, func(t time.Time) tea.Msg {
 					return tickMsg(t)
 				}))
@@ -349,12 +840,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// Phase 1: SSH port
 				m.deployStatus = "Phase 1/3: SSH port"
 				if msg.phaseStatus == "waiting" {
-					m.deployLogs = append(m.deployLogs, fmt.Sprintf("[%s] [Phase 1/3] Waiting for SSH port...", formatElapsed(elapsed)))
+					m.deployLogs = m.logEvent("deploy", m.deployingSensorName, "", audit.LevelInfo, fmt.Sprintf("[%s] [Phase 1/3] Waiting for SSH port...", formatElapsed(elapsed)))
 					cmds = append(cmds, tea.Tick(10*time.Second, func(t time.Time) tea.Msg {
 						return tickMsg(t)
 					}))
 				} else {
-					m.deployLogs = append(m.deployLogs, fmt.Sprintf("[%s] [Phase 1/3] ✓ SSH port accessible (%s)", formatElapsed(elapsed), formatElapsed(phaseElapsed)))
+					m.deployLogs = m.logEvent("deploy", m.deployingSensorName, "", audit.LevelInfo, fmt.Sprintf("[%s] [Phase 1/3] ✓ SSH port accessible (%s)", formatElapsed(elapsed), formatElapsed(phaseElapsed)))
 					// Continue to phase 2 immediately
 					cmds = append(cmds, m.checkDeployStatus())
 				}
@@ -362,15 +853,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// Phase 2: SSH service
 				m.deployStatus = "Phase 2/3: SSH service"
 				if msg.phaseStatus == "waiting" {
-					m.deployLogs = append(m.deployLogs, fmt.Sprintf("[%s] [Phase 2/3] Waiting for SSH service...", formatElapsed(elapsed)))
+					m.deployLogs = m.logEvent("deploy", m.deployingSensorName, "", audit.LevelInfo, fmt.Sprintf("[%s] [Phase 2/3] Waiting for SSH service...", formatElapsed(elapsed)))
 					cmds = append(cmds, tea.Tick(10*time.Second, func(t time.Time) tea.Msg {
 						return tickMsg(t)
 					}))
 				} else {
-					m.deployLogs = append(m.deployLogs, fmt.Sprintf("[%s] [Phase 2/3] ✓ SSH service ready (%s)", formatElapsed(elapsed), formatElapsed(phaseElapsed)))
-					m.deployLogs = append(m.deployLogs, "")
-					m.deployLogs = append(m.deployLogs, "[Phase 3/3] Waiting for sensor seeding (system.seeded=1)...")
-					m.deployLogs = append(m.deployLogs, "This can take 60+ minutes for initial seeding...")
+					m.deployLogs = m.logEvent("deploy", m.deployingSensorName, "", audit.LevelInfo, fmt.Sprintf("[%s] [Phase 2/3] ✓ SSH service ready (%s)", formatElapsed(elapsed), formatElapsed(phaseElapsed)))
+					m.deployLogs = m.logEvent("deploy", m.deployingSensorName, "", audit.LevelInfo, "")
+					m.deployLogs = m.logEvent("deploy", m.deployingSensorName, "", audit.LevelInfo, "[Phase 3/3] Waiting for sensor seeding (system.seeded=1)...")
+					m.deployLogs = m.logEvent("deploy", m.deployingSensorName, "", audit.LevelInfo, "This can take 60+ minutes for initial seeding...")
 					// Continue to phase 3 immediately
 					cmds = append(cmds, m.checkDeployStatus())
 				}
@@ -382,16 +873,16 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					if msg.seededValue != "" {
 						seededInfo = fmt.Sprintf(" (system.seeded=%s)", msg.seededValue)
 					}
-					m.deployLogs = append(m.deployLogs, fmt.Sprintf("[%s] [Phase 3/3] Seeding in progress%s", formatElapsed(elapsed), seededInfo))
+					m.deployLogs = m.logEvent("deploy", m.deployingSensorName, "", audit.LevelInfo, fmt.Sprintf("[%s] [Phase 3/3] Seeding in progress%s", formatElapsed(elapsed), seededInfo))
 					cmds = append(cmds, tea.Tick(15*time.Second, func(t time.Time) tea.Msg {
 						return tickMsg(t)
 					}))
 				} else {
 					// Seeding complete!
-					m.deployLogs = append(m.deployLogs, fmt.Sprintf("[%s] [Phase 3/3] ✓ Seeding complete! (%s)", formatElapsed(elapsed), formatElapsed(phaseElapsed)))
-					m.deployLogs = append(m.deployLogs, "")
-					m.deployLogs = append(m.deployLogs, fmt.Sprintf("✓ Sensor is READY at %s", msg.ip))
-					m.deployLogs = append(m.deployLogs, fmt.Sprintf("Total deployment time: %s", formatElapsed(elapsed)))
+					m.deployLogs = m.logEvent("deploy", m.deployingSensorName, msg.ip, audit.LevelInfo, fmt.Sprintf("[%s] [Phase 3/3] ✓ Seeding complete! (%s)", formatElapsed(elapsed), formatElapsed(phaseElapsed)))
+					m.deployLogs = m.logEvent("deploy", m.deployingSensorName, msg.ip, audit.LevelInfo, "")
+					m.deployLogs = m.logEvent("deploy", m.deployingSensorName, msg.ip, audit.LevelInfo, fmt.Sprintf("✓ Sensor is READY at %s", msg.ip))
+					m.deployLogs = m.logEvent("deploy", m.deployingSensorName, msg.ip, audit.LevelInfo, fmt.Sprintf("Total deployment time: %s", formatElapsed(elapsed)))
 					cmds = append(cmds, func() tea.Msg {
 						return deployCompleteMsg{sensorName: m.deployingSensorName, ip: msg.ip}
 					})
@@ -399,14 +890,46 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
-		// Keep only last 20 log lines to avoid overflow
-		if len(m.deployLogs) > 20 {
-			m.deployLogs = m.deployLogs[len(m.deployLogs)-20:]
+		if m.deploying {
+			m.journal.Put(opjournal.Entry{
+				SensorName: m.deployingSensorName,
+				Kind:       opjournal.KindDeploy,
+				Phase:      int(m.deployPhase),
+				StartTime:  m.deployStartTime,
+				LogTail:    m.deployLogs,
+			})
+			m.jobManager.Update(jobs.Snapshot{
+				ID:         jobs.ID(jobs.KindDeploy, m.deployingSensorName),
+				SensorName: m.deployingSensorName,
+				Kind:       jobs.KindDeploy,
+				Status:     jobs.StatusRunning,
+				StartTime:  m.deployStartTime,
+				Phase:      m.deployStatus,
+				LogTail:    m.deployLogs,
+			})
 		}
 
 	case deployCompleteMsg:
+		event := deploy.EventSeedingComplete
+		if msg.err != nil {
+			event = deploy.EventFailed
+		}
+		if next, err := deploy.Transition(m.deployPhase, event); err == nil {
+			m.deployPhase = next
+		}
 		m.deploying = false
 		m.loading = false
+		m.journal.Remove(m.deployingSensorName)
+		deployStatus := jobs.StatusDone
+		if msg.err != nil {
+			deployStatus = jobs.StatusFailed
+		}
+		m.jobManager.Finish(jobs.ID(jobs.KindDeploy, m.deployingSensorName), deployStatus, msg.err)
+		if m.pendingConfigReload != nil {
+			reloaded := m.pendingConfigReload
+			m.pendingConfigReload = nil
+			m = m.applyConfigReload(reloaded)
+		}
 		if msg.err != nil {
 			m.errorMessage = fmt.Sprintf("Deployment failed: %v", msg.err)
 			m.view = ViewHome
@@ -417,6 +940,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmds = append(cmds, m.loadSensors())
 		}
 
+	case phaseChangedMsg:
+		if msg.ok {
+			cmds = append(cmds, m.waitForPhaseChangedEvent())
+		}
+
+	case jobRetryResultMsg:
+		if msg.err != nil {
+			m.jobManager.Finish(msg.id, jobs.StatusFailed, msg.err)
+		} else {
+			m.jobManager.Finish(msg.id, jobs.StatusDone, nil)
+		}
+
 	case upgradeInfoMsg:
 		m.loading = false
 		if msg.err != nil {
@@ -435,9 +970,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.upgrading = false
 			m.view = ViewOperations
 			m.errorMessage = fmt.Sprintf("Upgrade failed to start: %v", msg.err)
+			m.journal.Remove(m.upgradingSensorName)
+			m.jobManager.Finish(jobs.ID(jobs.KindUpgrade, m.upgradingSensorName), jobs.StatusFailed, msg.err)
 		} else {
-			m.upgradeLogs = append(m.upgradeLogs, "✓ Upgrade command executed successfully")
-			m.upgradeLogs = append(m.upgradeLogs, "Monitoring upgrade progress...")
+			m.upgradeLogs = m.logEvent("upgrade", m.upgradingSensorName, m.upgradingSensorIP, audit.LevelInfo, "✓ Upgrade command executed successfully")
+			m.upgradeLogs = m.logEvent("upgrade", m.upgradingSensorName, m.upgradingSensorIP, audit.LevelInfo, "Monitoring upgrade progress...")
 			// Start monitoring progress
 			cmds = append(cmds, tea.Tick(5*time.Second, func(t time.Time) tea.Msg {
 				return tickMsg(t)
@@ -446,32 +983,59 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case upgradeProgressMsg:
 		elapsed := time.Since(m.upgradeStartTime).Round(time.Second)
+		phase := "Verifying"
 
 		if msg.err != nil {
-			m.upgradeLogs = append(m.upgradeLogs, fmt.Sprintf("[%s] ⚠ Check error: %v", formatElapsed(elapsed), msg.err))
+			m.upgradeLogs = m.logEvent("upgrade", m.upgradingSensorName, m.upgradingSensorIP, audit.LevelWarn, fmt.Sprintf("[%s] ⚠ Check error: %v", formatElapsed(elapsed), msg.err))
+			phase = "Check error, retrying"
 			// Continue monitoring even on error - no timeout
 		} else if !msg.sshAvailable {
-			m.upgradeLogs = append(m.upgradeLogs, fmt.Sprintf("[%s] Sensor rebooting... (SSH unavailable)", formatElapsed(elapsed)))
+			m.upgradeLogs = m.logEvent("upgrade", m.upgradingSensorName, m.upgradingSensorIP, audit.LevelInfo, fmt.Sprintf("[%s] Sensor rebooting... (SSH unavailable)", formatElapsed(elapsed)))
+			phase = "Rebooting"
 		} else if msg.processRunning {
-			m.upgradeLogs = append(m.upgradeLogs, fmt.Sprintf("[%s] Upgrade in progress...", formatElapsed(elapsed)))
+			m.upgradeLogs = m.logEvent("upgrade", m.upgradingSensorName, m.upgradingSensorIP, audit.LevelInfo, fmt.Sprintf("[%s] Upgrade in progress...", formatElapsed(elapsed)))
+			phase = "Upgrading"
 		} else if msg.newVersion != "" && msg.newVersion != "unknown" {
 			// Upgrade complete!
-			m.upgradeLogs = append(m.upgradeLogs, "")
+			m.upgradeLogs = m.logEvent("upgrade", m.upgradingSensorName, m.upgradingSensorIP, audit.LevelInfo, "")
 			if msg.newVersion != m.upgradeCurrentVersion {
-				m.upgradeLogs = append(m.upgradeLogs, fmt.Sprintf("✓ Upgraded from %s to %s", m.upgradeCurrentVersion, msg.newVersion))
+				m.upgradeLogs = m.logEvent("upgrade", m.upgradingSensorName, m.upgradingSensorIP, audit.LevelInfo, fmt.Sprintf("✓ Upgraded from %s to %s", m.upgradeCurrentVersion, msg.newVersion))
 			} else {
-				m.upgradeLogs = append(m.upgradeLogs, fmt.Sprintf("✓ Upgrade complete: %s", msg.newVersion))
+				m.upgradeLogs = m.logEvent("upgrade", m.upgradingSensorName, m.upgradingSensorIP, audit.LevelInfo, fmt.Sprintf("✓ Upgrade complete: %s", msg.newVersion))
 			}
-			m.upgradeLogs = append(m.upgradeLogs, fmt.Sprintf("Completed in %s", formatElapsed(elapsed)))
+			m.upgradeLogs = m.logEvent("upgrade", m.upgradingSensorName, m.upgradingSensorIP, audit.LevelInfo, fmt.Sprintf("Completed in %s", formatElapsed(elapsed)))
 			m.upgrading = false
+			m.journal.Remove(m.upgradingSensorName)
+			m.jobManager.Finish(jobs.ID(jobs.KindUpgrade, m.upgradingSensorName), jobs.StatusDone, nil)
+			if m.pendingConfigReload != nil {
+				reloaded := m.pendingConfigReload
+				m.pendingConfigReload = nil
+				m = m.applyConfigReload(reloaded)
+			}
 			return m, nil
 		} else {
-			m.upgradeLogs = append(m.upgradeLogs, fmt.Sprintf("[%s] Verifying...", formatElapsed(elapsed)))
+			m.upgradeLogs = m.logEvent("upgrade", m.upgradingSensorName, m.upgradingSensorIP, audit.LevelInfo, fmt.Sprintf("[%s] Verifying...", formatElapsed(elapsed)))
 		}
 
-		// Keep only last 15 log lines
-		if len(m.upgradeLogs) > 15 {
-			m.upgradeLogs = m.upgradeLogs[len(m.upgradeLogs)-15:]
+		if m.upgrading {
+			m.journal.Put(opjournal.Entry{
+				SensorName:    m.upgradingSensorName,
+				IP:            m.upgradingSensorIP,
+				Kind:          opjournal.KindUpgrade,
+				StartTime:     m.upgradeStartTime,
+				TargetVersion: m.upgradeTargetVersion,
+				LogTail:       m.upgradeLogs,
+			})
+			m.jobManager.Update(jobs.Snapshot{
+				ID:         jobs.ID(jobs.KindUpgrade, m.upgradingSensorName),
+				SensorName: m.upgradingSensorName,
+				SensorIP:   m.upgradingSensorIP,
+				Kind:       jobs.KindUpgrade,
+				Status:     jobs.StatusRunning,
+				StartTime:  m.upgradeStartTime,
+				Phase:      phase,
+				LogTail:    m.upgradeLogs,
+			})
 		}
 
 		// Continue monitoring indefinitely (every 10 seconds) - no timeout
@@ -481,17 +1045,28 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case upgradeCompleteMsg:
 		m.upgrading = false
+		m.journal.Remove(m.upgradingSensorName)
+		upgradeStatus := jobs.StatusDone
+		if msg.err != nil {
+			upgradeStatus = jobs.StatusFailed
+		}
+		m.jobManager.Finish(jobs.ID(jobs.KindUpgrade, m.upgradingSensorName), upgradeStatus, msg.err)
+		if m.pendingConfigReload != nil {
+			reloaded := m.pendingConfigReload
+			m.pendingConfigReload = nil
+			m = m.applyConfigReload(reloaded)
+		}
 		if msg.err != nil {
-			m.upgradeLogs = append(m.upgradeLogs, "")
-			m.upgradeLogs = append(m.upgradeLogs, fmt.Sprintf("✗ Upgrade failed: %v", msg.err))
+			m.upgradeLogs = m.logEvent("upgrade", m.upgradingSensorName, m.upgradingSensorIP, audit.LevelError, "")
+			m.upgradeLogs = m.logEvent("upgrade", m.upgradingSensorName, m.upgradingSensorIP, audit.LevelError, fmt.Sprintf("✗ Upgrade failed: %v", msg.err))
 		}
 
 	case enableFeaturesResultMsg:
 		m.enablingFeatures = false
 		elapsed := time.Since(m.enableFeaturesStart).Round(time.Second)
 		if msg.err != nil {
-			m.enableFeaturesLogs = append(m.enableFeaturesLogs, "")
-			m.enableFeaturesLogs = append(m.enableFeaturesLogs, fmt.Sprintf("✗ Failed: %v", msg.err))
+			m.enableFeaturesLogs = m.logEvent("enable_features", m.enableFeaturesSensorName, "", audit.LevelError, "")
+			m.enableFeaturesLogs = m.logEvent("enable_features", m.enableFeaturesSensorName, "", audit.LevelError, fmt.Sprintf("✗ Failed: %v", msg.err))
 			if msg.output != "" {
 				// Add last few lines of output for debugging
 				lines := strings.Split(msg.output, "\n")
@@ -500,27 +1075,32 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 				for _, line := range lines {
 					if strings.TrimSpace(line) != "" {
-						m.enableFeaturesLogs = append(m.enableFeaturesLogs, "  "+line)
+						m.enableFeaturesLogs = m.logEvent("enable_features", m.enableFeaturesSensorName, "", audit.LevelError, "  "+line)
 					}
 				}
 			}
 		} else {
-			m.enableFeaturesLogs = append(m.enableFeaturesLogs, "")
-			m.enableFeaturesLogs = append(m.enableFeaturesLogs, fmt.Sprintf("✓ Features enabled successfully in %s", formatElapsed(elapsed)))
-			m.enableFeaturesLogs = append(m.enableFeaturesLogs, "")
-			m.enableFeaturesLogs = append(m.enableFeaturesLogs, "Enabled features:")
-			m.enableFeaturesLogs = append(m.enableFeaturesLogs, "  ✓ HTTP access")
-			m.enableFeaturesLogs = append(m.enableFeaturesLogs, "  ✓ YARA engine")
-			m.enableFeaturesLogs = append(m.enableFeaturesLogs, "  ✓ Suricata IDS")
-			m.enableFeaturesLogs = append(m.enableFeaturesLogs, "  ✓ SmartPCAP")
+			m.enableFeaturesLogs = m.logEvent("enable_features", m.enableFeaturesSensorName, "", audit.LevelInfo, "")
+			m.enableFeaturesLogs = m.logEvent("enable_features", m.enableFeaturesSensorName, "", audit.LevelInfo, fmt.Sprintf("✓ Features enabled successfully in %s", formatElapsed(elapsed)))
+			m.enableFeaturesLogs = m.logEvent("enable_features", m.enableFeaturesSensorName, "", audit.LevelInfo, "")
+			m.enableFeaturesLogs = m.logEvent("enable_features", m.enableFeaturesSensorName, "", audit.LevelInfo, "Enabled features:")
+			m.enableFeaturesLogs = m.logEvent("enable_features", m.enableFeaturesSensorName, "", audit.LevelInfo, "  ✓ HTTP access")
+			m.enableFeaturesLogs = m.logEvent("enable_features", m.enableFeaturesSensorName, "", audit.LevelInfo, "  ✓ YARA engine")
+			m.enableFeaturesLogs = m.logEvent("enable_features", m.enableFeaturesSensorName, "", audit.LevelInfo, "  ✓ Suricata IDS")
+			m.enableFeaturesLogs = m.logEvent("enable_features", m.enableFeaturesSensorName, "", audit.LevelInfo, "  ✓ SmartPCAP")
+		}
+		enableFeaturesStatus := jobs.StatusDone
+		if msg.err != nil {
+			enableFeaturesStatus = jobs.StatusFailed
 		}
+		m.jobManager.Finish(jobs.ID(jobs.KindEnableFeatures, m.enableFeaturesSensorName), enableFeaturesStatus, msg.err)
 
 	case fleetResultMsg:
 		m.addingToFleet = false
 		elapsed := time.Since(m.fleetStart).Round(time.Second)
 		if msg.err != nil {
-			m.fleetLogs = append(m.fleetLogs, "")
-			m.fleetLogs = append(m.fleetLogs, fmt.Sprintf("✗ Failed: %v", msg.err))
+			m.fleetLogs = m.logEvent("add_to_fleet", m.fleetSensorName, "", audit.LevelError, "")
+			m.fleetLogs = m.logEvent("add_to_fleet", m.fleetSensorName, "", audit.LevelError, fmt.Sprintf("✗ Failed: %v", msg.err))
 			if msg.output != "" {
 				lines := strings.Split(msg.output, "\n")
 				if len(lines) > 5 {
@@ -528,14 +1108,19 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 				for _, line := range lines {
 					if strings.TrimSpace(line) != "" {
-						m.fleetLogs = append(m.fleetLogs, "  "+line)
+						m.fleetLogs = m.logEvent("add_to_fleet", m.fleetSensorName, "", audit.LevelError, "  "+line)
 					}
 				}
 			}
 		} else {
-			m.fleetLogs = append(m.fleetLogs, "")
-			m.fleetLogs = append(m.fleetLogs, fmt.Sprintf("✓ Sensor added to fleet manager in %s", formatElapsed(elapsed)))
+			m.fleetLogs = m.logEvent("add_to_fleet", m.fleetSensorName, "", audit.LevelInfo, "")
+			m.fleetLogs = m.logEvent("add_to_fleet", m.fleetSensorName, "", audit.LevelInfo, fmt.Sprintf("✓ Sensor added to fleet manager in %s", formatElapsed(elapsed)))
 		}
+		fleetStatus := jobs.StatusDone
+		if msg.err != nil {
+			fleetStatus = jobs.StatusFailed
+		}
+		m.jobManager.Finish(jobs.ID(jobs.KindAddToFleet, m.fleetSensorName), fleetStatus, msg.err)
 
 	case trafficConfigResultMsg:
 		m.loading = false
@@ -550,6 +1135,25 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, tea.ExecProcess(m.sshCommand(msg.ip), func(err error) tea.Msg {
 			return nil
 		})
+
+	case batchEventMsg:
+		if !msg.ok {
+			m.batchActive = false
+			return m, nil
+		}
+		state, ok := m.batchStates[msg.event.Sensor]
+		if !ok {
+			state = &batchSensorState{started: time.Now()}
+			m.batchStates[msg.event.Sensor] = state
+		}
+		state.phase = msg.event.Phase
+		if msg.event.Message != "" {
+			state.message = msg.event.Message
+		}
+		if msg.event.Err != nil {
+			state.err = msg.event.Err
+		}
+		return m, m.waitForBatchEvent()
 	}
 
 	return m, tea.Batch(cmds...)
@@ -568,7 +1172,7 @@ func (m Model) View() string {
 	case ViewHealth:
 		return m.renderHealth()
 	case ViewHelp:
-		return ui.RenderHelp(m.styles, m.helpContext())
+		return ui.RenderHelp(m.styles, m.helpContext(), m.keymap)
 	case ViewConfirmDelete:
 		return m.renderConfirmDelete()
 	case ViewConfirmDeploy:
@@ -591,21 +1195,105 @@ func (m Model) View() string {
 		return m.renderAddingToFleet()
 	case ViewTrafficGenerator:
 		return m.renderTrafficGenerator()
+	case ViewTrafficProfile:
+		return m.renderTrafficProfile()
 	case ViewTrafficStart:
 		return m.renderTrafficStart()
+	case ViewTrafficGenProfile:
+		return m.renderTrafficGenProfile()
+	case ViewTrafficGenProfileNew:
+		return m.renderTrafficGenProfileNew()
+	case ViewConfirmBatch:
+		return m.renderConfirmBatch()
+	case ViewBatchOperations:
+		return m.renderBatchOperations()
+	case ViewTrafficScenario:
+		return m.renderTrafficScenario()
+	case ViewTrafficScenarioReport:
+		return m.renderTrafficScenarioReport()
+	case ViewBackgroundJobs:
+		return m.renderBackgroundJobs()
 	default:
 		return m.renderHome()
 	}
 }
 
+// actionContext names the keymap context for the current view: "operations"
+// while drilled into a sensor, "main" everywhere else (including the global
+// quit/help/cycle_theme bindings, which always live under "main").
+func (m Model) actionContext() string {
+	if m.view == ViewOperations {
+		return "operations"
+	}
+	return "main"
+}
+
+// resolveAction resolves key to a bound action, preferring the current
+// view's context and falling back to the global "main" bindings so
+// quit/help/cycle_theme work from any view.
+func (m Model) resolveAction(key string) (ui.Action, bool) {
+	if action, ok := m.keymap.Resolve(m.actionContext(), key); ok {
+		return action, true
+	}
+	return m.keymap.Resolve("main", key)
+}
+
+func (m Model) isSequencePrefix(key string) bool {
+	return m.keymap.IsSequencePrefix(m.actionContext(), key) || m.keymap.IsSequencePrefix("main", key)
+}
+
+// canonicalKeyMsg synthesizes a KeyMsg for action's default key, so a key
+// rebound in the keymap still flows through the existing literal-key switch
+// statements below unchanged.
+func canonicalKeyMsg(action ui.Action) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(ui.DefaultKeyFor(action))}
+}
+
 // Key handling
 func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.keymap != nil {
+		key := msg.String()
+		switch {
+		case m.pendingKey != "":
+			combined := m.pendingKey + " " + key
+			m.pendingKey = ""
+			if action, ok := m.resolveAction(combined); ok {
+				msg = canonicalKeyMsg(action)
+			}
+		case m.isSequencePrefix(key):
+			m.pendingKey = key
+			return m, nil
+		default:
+			if action, ok := m.resolveAction(key); ok {
+				msg = canonicalKeyMsg(action)
+			}
+		}
+	}
+
 	// Global shortcuts
 	switch msg.String() {
 	case "ctrl+c", "q":
 		if m.view == ViewHome {
+			if m.cancel != nil {
+				m.cancel()
+			}
+			if m.sshClient != nil {
+				m.sshClient.Close()
+			}
+			if m.configWatcher != nil {
+				m.configWatcher.Close()
+			}
 			return m, tea.Quit
 		}
+		if m.view == ViewBatchOperations && m.batchActive {
+			if m.batchCancel != nil {
+				m.batchCancel()
+			}
+			m.batchActive = false
+			m.statusMessage = "Batch operation cancelled; jobs already running may still finish."
+			m.view = ViewHome
+			return m, m.loadSensors()
+		}
 		m.view = ViewHome
 		return m, nil
 
@@ -615,9 +1303,17 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case "t":
-		// Cycle theme
-		m.themeName = ui.NextTheme(m.themeName)
-		m.styles = ui.NewStyles(ui.GetTheme(m.themeName))
+		// Cycle theme, then through any user-defined stylesets
+		m.themeName, m.stylesetName = m.nextThemeOrStyleset()
+		styles, err := m.buildStyles()
+		m.styles = styles
+		if err != nil {
+			m.errorMessage = err.Error()
+		}
+		m.config.Theme = m.themeName
+		if err := m.config.Save(); err != nil {
+			m.errorMessage = fmt.Sprintf("Failed to save theme: %v", err)
+		}
 		return m, nil
 	}
 
@@ -673,9 +1369,39 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	if m.view == ViewTrafficGenerator {
 		return m.handleTrafficGeneratorKeys(msg)
 	}
+	if m.view == ViewTrafficProfile {
+		return m.handleTrafficProfileKeys(msg)
+	}
 	if m.view == ViewTrafficStart {
 		return m.handleTrafficStartKeys(msg)
 	}
+	if m.view == ViewTrafficGenProfile {
+		return m.handleTrafficGenProfileKeys(msg)
+	}
+	if m.view == ViewTrafficGenProfileNew {
+		return m.handleTrafficGenProfileNewKeys(msg)
+	}
+
+	// Handle batch operation views
+	if m.view == ViewConfirmBatch {
+		return m.handleConfirmBatchKeys(msg)
+	}
+	if m.view == ViewBatchOperations {
+		return m.handleBatchOperationsKeys(msg)
+	}
+
+	// Handle traffic scenario views
+	if m.view == ViewTrafficScenario {
+		return m.handleTrafficScenarioKeys(msg)
+	}
+	if m.view == ViewTrafficScenarioReport {
+		return m.handleTrafficScenarioReportKeys(msg)
+	}
+
+	// Handle background jobs view
+	if m.view == ViewBackgroundJobs {
+		return m.handleBackgroundJobsKeys(msg)
+	}
 
 	// View-specific handling
 	switch m.view {
@@ -717,6 +1443,9 @@ func (m Model) handleHomeKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, tea.Batch(m.loadSensors(), m.spinner.Tick)
 	case "n":
 		m.view = ViewConfirmDeploy
+	case "b":
+		m.jobsCursor = 0
+		m.view = ViewBackgroundJobs
 	case "m":
 		m.multiSelect = !m.multiSelect
 	case " ":
@@ -751,7 +1480,7 @@ func (m Model) handleOperationsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	}
 
 	sensor := m.sensors[m.selectedIdx]
-	
+
 	// Check if sensor was deleted
 	if sensor.Deleted || sensor.Status == models.StatusDeleted {
 		m.errorMessage = fmt.Sprintf("Sensor '%s' was deleted. Removing from list.", sensor.ShortID())
@@ -774,12 +1503,18 @@ func (m Model) handleOperationsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		m.errorMessage = fmt.Sprintf("Cannot connect - sensor status is %s", sensor.Status)
 	case "f", "2":
+		if selected := m.selectedSensors(); m.multiSelect && len(selected) > 0 {
+			return m.startBatchConfirm("enable features", selected)
+		}
 		if sensor.IsReady() {
 			m.view = ViewEnableFeatures
 		} else {
 			m.errorMessage = fmt.Sprintf("Cannot enable features - sensor status is %s", sensor.Status)
 		}
 	case "3":
+		if selected := m.selectedSensors(); m.multiSelect && len(selected) > 0 {
+			return m.startBatchConfirm("add to fleet", selected)
+		}
 		if sensor.IsReady() {
 			m.view = ViewFleetManager
 		} else {
@@ -787,18 +1522,14 @@ func (m Model) handleOperationsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 	case "4":
 		if sensor.IsReady() {
-			// Initialize traffic generator defaults
-			m.trafficTargetIP = ""
-			m.trafficTargetPort = "5555"
-			m.trafficProtocol = "udp"
-			m.trafficPPS = "1000"
-			m.trafficDuration = "0"
-			m.trafficInputStep = 0
 			m.view = ViewTrafficGenerator
 		} else {
 			m.errorMessage = fmt.Sprintf("Cannot configure traffic - sensor status is %s", sensor.Status)
 		}
 	case "u", "5":
+		if selected := m.selectedSensors(); m.multiSelect && len(selected) > 0 {
+			return m.startBatchConfirm("upgrade", selected)
+		}
 		if sensor.IsReady() {
 			// Start loading upgrade info
 			m.loading = true
@@ -811,15 +1542,63 @@ func (m Model) handleOperationsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.errorMessage = fmt.Sprintf("Cannot upgrade - sensor status is %s", sensor.Status)
 		}
 	case "d", "6":
+		if selected := m.selectedSensors(); m.multiSelect && len(selected) > 0 {
+			return m.startBatchConfirm("delete", selected)
+		}
 		m.deletingSensorName = sensor.Name
 		m.view = ViewConfirmDelete
 	case "h", "7":
 		if sensor.IsReady() {
 			m.view = ViewHealth
-		} else {
-			m.errorMessage = fmt.Sprintf("Cannot view health - sensor status is %s", sensor.Status)
+			m.refreshPaused = false
+			return m, refreshTickCmd(m.config.DashboardRefreshInterval)
+		}
+		m.errorMessage = fmt.Sprintf("Cannot view health - sensor status is %s", sensor.Status)
+	case "9":
+		if entry, ok := m.resumableOps[sensor.Name]; ok && !m.deploying && !m.upgrading {
+			newModel, cmd := m.attachToOperation(m.selectedIdx, entry)
+			return newModel, cmd
+		}
+	case "s":
+		if !sensor.IsReady() {
+			m.errorMessage = fmt.Sprintf("Cannot run traffic scenario - sensor status is %s", sensor.Status)
+			break
+		}
+		scenarios, err := traffic.ListScenarios()
+		if err != nil {
+			m.errorMessage = fmt.Sprintf("Cannot list scenarios: %v", err)
+			break
+		}
+		if len(scenarios) == 0 {
+			m.errorMessage = fmt.Sprintf("No scenario files found in %s", traffic.ScenariosDir())
+			break
+		}
+		m.trafficScenarios = scenarios
+		m.trafficScenarioIdx = 0
+		m.view = ViewTrafficScenario
+	}
+	return m, nil
+}
+
+// selectedSensors returns every sensor with Selected set, so an operation
+// can fan out across a multi-select instead of acting on just selectedIdx.
+func (m Model) selectedSensors() []*models.Sensor {
+	var selected []*models.Sensor
+	for _, sensor := range m.sensors {
+		if sensor.Selected {
+			selected = append(selected, sensor)
 		}
 	}
+	return selected
+}
+
+// startBatchConfirm routes to ViewConfirmBatch for op against sensors,
+// mirroring the single-sensor confirm-then-run flow (e.g. ViewConfirmDelete)
+// one level up, across a whole multi-select at once.
+func (m Model) startBatchConfirm(op string, sensors []*models.Sensor) (tea.Model, tea.Cmd) {
+	m.batchOp = op
+	m.batchSensors = sensors
+	m.view = ViewConfirmBatch
 	return m, nil
 }
 
@@ -827,10 +1606,26 @@ func (m Model) handleHealthKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "b", "esc", "enter":
 		m.view = ViewOperations
+	case "p":
+		return m.toggleRefreshPause()
 	}
 	return m, nil
 }
 
+// toggleRefreshPause flips m.refreshPaused for the live-refreshing
+// dashboard loop (ViewHealth and the in-flight operation views). Resuming
+// restarts the loop, which dies while paused - see the refreshSensorMsg
+// case in Update.
+func (m Model) toggleRefreshPause() (Model, tea.Cmd) {
+	m.refreshPaused = !m.refreshPaused
+	if m.refreshPaused {
+		m.statusMessage = "Refresh paused - press p to resume"
+		return m, nil
+	}
+	m.statusMessage = ""
+	return m, refreshTickCmd(m.config.DashboardRefreshInterval)
+}
+
 func (m Model) handleConfirmDelete(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "y", "Y":
@@ -853,94 +1648,369 @@ func (m Model) handleConfirmDelete(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-func (m Model) handleConfirmDeploy(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+func (m Model) handleConfirmBatchKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "y", "Y":
-		// Start deployment
-		m.view = ViewDeploying
-		m.deploying = true
-		m.deployStartTime = time.Now()
-		m.deployPhaseStart = time.Now()
-		m.deployStatus = "creating"
-		m.deployPhase = 0
-		m.deployLogs = []string{"Creating new sensor..."}
-		return m, tea.Batch(
-			m.createSensor(),
-			m.spinner.Tick,
-		)
+		if len(m.batchSensors) == 0 {
+			m.view = ViewOperations
+			return m, nil
+		}
+
+		jobs := make(map[string]batch.Job, len(m.batchSensors))
+		order := make([]string, 0, len(m.batchSensors))
+		states := make(map[string]*batchSensorState, len(m.batchSensors))
+		for _, sensor := range m.batchSensors {
+			order = append(order, sensor.Name)
+			states[sensor.Name] = &batchSensorState{started: time.Now()}
+			switch m.batchOp {
+			case "delete":
+				jobs[sensor.Name] = m.batchDeleteJob(sensor.Name)
+			case "upgrade":
+				jobs[sensor.Name] = m.batchUpgradeJob(sensor.IP)
+			case "enable features":
+				jobs[sensor.Name] = m.batchEnableFeaturesJob(sensor.IP)
+			case "add to fleet":
+				jobs[sensor.Name] = m.batchAddToFleetJob(sensor.IP)
+			}
+		}
+		sort.Strings(order)
+
+		ctx, cancel := context.WithCancel(m.ctx)
+		m.batchCancel = cancel
+		m.batchOrder = order
+		m.batchStates = states
+		retry := batch.DefaultRetryPolicy()
+		retry.MaxElapsedTime = m.config.BatchRetryTimeout
+		m.batchEvents = batch.NewGroup(m.config.MaxParallelOps).WithRetry(retry).Run(ctx, jobs)
+		m.batchActive = true
+		m.view = ViewBatchOperations
+		return m, tea.Batch(m.waitForBatchEvent(), m.spinner.Tick)
 	case "n", "N", "esc":
-		m.view = ViewHome
+		m.batchOp = ""
+		m.batchSensors = nil
+		m.view = ViewOperations
 	}
 	return m, nil
 }
 
-func (m Model) handleDeployingKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	if !m.deploying {
-		// Deployment complete - any key returns home
+func (m Model) handleBatchOperationsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if !m.batchActive {
+		// Batch complete - any key returns home and refreshes the list.
 		m.view = ViewHome
 		return m, m.loadSensors()
 	}
 
 	switch msg.String() {
 	case "esc":
-		// Allow canceling (sensor will still be created, but we go back to home)
-		m.deploying = false
+		if m.batchCancel != nil {
+			m.batchCancel()
+		}
+		m.batchActive = false
+		m.statusMessage = "Batch operation cancelled; jobs already running may still finish."
 		m.view = ViewHome
-		m.statusMessage = "Deployment continuing in background. Refresh to see status."
+		return m, m.loadSensors()
 	}
 	return m, nil
 }
 
-func (m Model) handleUpgradeKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+func (m Model) handleTrafficScenarioKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
-	case "1":
-		// Upgrade to latest
-		if len(m.upgradeAvailableVersions) == 0 {
-			m.errorMessage = "No updates available via corelight-client"
+	case "up", "k":
+		if m.trafficScenarioIdx > 0 {
+			m.trafficScenarioIdx--
+		}
+	case "down", "j":
+		if m.trafficScenarioIdx < len(m.trafficScenarios)-1 {
+			m.trafficScenarioIdx++
+		}
+	case "enter":
+		if m.selectedIdx >= len(m.sensors) || m.trafficScenarioIdx >= len(m.trafficScenarios) {
 			return m, nil
 		}
-		m.upgradeOption = 1
-		m.upgradeTargetVersion = "latest"
-		m.view = ViewUpgradeConfirm
-	case "2":
-		// Upgrade to specific version - for now, prompt in confirm view
-		m.upgradeOption = 2
-		m.upgradeTargetVersion = "" // Will be set in confirm view
-		m.view = ViewUpgradeConfirm
-	case "3", "b", "esc":
+		sensor := m.sensors[m.selectedIdx]
+		name := m.trafficScenarios[m.trafficScenarioIdx]
+		m.loading = true
+		m.loadingMsg = fmt.Sprintf("Running scenario %s...", name)
+		m.trafficScenarioSensor = sensor.Name
+		m.trafficScenarioStart = time.Now()
+		m.jobManager.Update(jobs.Snapshot{
+			ID:         jobs.ID(jobs.KindTraffic, sensor.Name),
+			SensorName: sensor.Name,
+			SensorIP:   sensor.IP,
+			Kind:       jobs.KindTraffic,
+			Status:     jobs.StatusRunning,
+			StartTime:  m.trafficScenarioStart,
+			Phase:      fmt.Sprintf("Running scenario %s", name),
+		})
+		return m, tea.Batch(m.runTrafficScenario(sensor.IP, name), m.spinner.Tick)
+	case "b", "esc":
 		m.view = ViewOperations
 	}
 	return m, nil
 }
 
-func (m Model) handleUpgradeConfirmKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+func (m Model) handleTrafficScenarioReportKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
-	case "y", "Y":
-		if m.selectedIdx >= len(m.sensors) {
-			m.view = ViewOperations
-			return m, nil
-		}
-		sensor := m.sensors[m.selectedIdx]
-		
-		// For specific version upgrade, use current version if not set
-		if m.upgradeOption == 2 && m.upgradeTargetVersion == "" {
-			m.upgradeTargetVersion = m.upgradeCurrentVersion
+	case "b", "esc", "enter":
+		m.trafficScenarioReport = nil
+		m.view = ViewOperations
+	}
+	return m, nil
+}
+
+// handleBackgroundJobsKeys drives the job list and its detail pane. The
+// detail pane re-reads m.jobManager on every render, so a still-running
+// job's phase and log tail keep advancing live - the operation was never
+// tied to this view in the first place, only its visibility was.
+func (m Model) handleBackgroundJobsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.jobsDetailID != "" {
+		switch msg.String() {
+		case "b", "esc", "enter":
+			m.jobsDetailID = ""
+		case "c":
+			if snap, ok := m.jobManager.Get(m.jobsDetailID); ok {
+				if snap.Status == jobs.StatusRunning || snap.Status == jobs.StatusRetrying {
+					m.jobManager.Finish(snap.ID, jobs.StatusFailed, fmt.Errorf("cancelled by user"))
+				}
+			}
+		case "r":
+			if retried, ok := m.jobManager.Retry(m.jobsDetailID); ok {
+				return m, m.retryJob(retried)
+			}
 		}
-		
-		// Start the upgrade
-		m.view = ViewUpgrading
-		m.upgrading = true
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "up", "k":
+		if m.jobsCursor > 0 {
+			m.jobsCursor--
+		}
+	case "down", "j":
+		snaps := m.jobManager.List()
+		if m.jobsCursor < len(snaps)-1 {
+			m.jobsCursor++
+		}
+	case "enter":
+		snaps := m.jobManager.List()
+		if m.jobsCursor < len(snaps) {
+			m.jobsDetailID = snaps[m.jobsCursor].ID
+		}
+	case "c":
+		snaps := m.jobManager.List()
+		if m.jobsCursor < len(snaps) {
+			snap := snaps[m.jobsCursor]
+			if snap.Status == jobs.StatusRunning || snap.Status == jobs.StatusRetrying {
+				m.jobManager.Finish(snap.ID, jobs.StatusFailed, fmt.Errorf("cancelled by user"))
+			}
+		}
+	case "r":
+		snaps := m.jobManager.List()
+		if m.jobsCursor < len(snaps) {
+			snap := snaps[m.jobsCursor]
+			if retried, ok := m.jobManager.Retry(snap.ID); ok {
+				return m, m.retryJob(retried)
+			}
+		}
+	case "b", "esc":
+		m.view = ViewHome
+	}
+	return m, nil
+}
+
+// retryJob re-runs a failed enable-features or add-to-fleet job's sensor
+// script headlessly and reports the outcome as a jobRetryResultMsg.
+// Deploy/upgrade retries go through attachToOperation's opjournal-backed
+// resume path instead, since those already know how to pick back up
+// mid-flight rather than starting the whole operation over.
+func (m Model) retryJob(snap jobs.Snapshot) tea.Cmd {
+	sshClient := m.sshClient
+	return func() tea.Msg {
+		var err error
+		switch snap.Kind {
+		case jobs.KindEnableFeatures:
+			_, err = sshClient.EnableFeatures(snap.SensorIP)
+		case jobs.KindAddToFleet:
+			_, err = sshClient.AddToFleetManager(snap.SensorIP)
+		default:
+			err = fmt.Errorf("retry from Background Jobs isn't supported for %s jobs", snap.Kind)
+		}
+		return jobRetryResultMsg{id: snap.ID, err: err}
+	}
+}
+
+// runTrafficScenario loads name from traffic.ScenariosDir and runs it
+// against ip, blocking until every stage completes (or ctx is cancelled).
+func (m Model) runTrafficScenario(ip, name string) tea.Cmd {
+	sshClient := m.sshClient
+	ctx := m.ctx
+	return func() tea.Msg {
+		scenario, err := traffic.LoadScenario(filepath.Join(traffic.ScenariosDir(), name))
+		if err != nil {
+			return trafficScenarioDoneMsg{err: err}
+		}
+		report := traffic.NewRunner(sshClient).Run(ctx, ip, scenario)
+		return trafficScenarioDoneMsg{report: report}
+	}
+}
+
+// attachToOperation restores m.view and the deploy/upgrade tracking fields
+// from a journaled opjournal.Entry, then re-enters the same polling loop
+// checkDeployStatus/checkUpgradeProgress use for an operation just
+// started - so resuming picks up wherever the sensor-side work actually
+// is, rather than trusting the journal's last snapshot as current.
+func (m Model) attachToOperation(idx int, entry opjournal.Entry) (Model, tea.Cmd) {
+	m.selectedIdx = idx
+	delete(m.resumableOps, entry.SensorName)
+
+	m.refreshPaused = false
+	switch entry.Kind {
+	case opjournal.KindDeploy:
+		m.view = ViewDeploying
+		m.deploying = true
+		m.deployingSensorName = entry.SensorName
+		m.deployStartTime = entry.StartTime
+		m.deployPhaseStart = time.Now()
+		m.deployPhase = deploy.Phase(entry.Phase)
+		m.deployPhaseEvents = make(chan deploy.PhaseChangedEvent, 8)
+		m.audit.Seed("deploy", m.deployingSensorName, entry.LogTail)
+		m.deployLogs = m.logEvent("deploy", m.deployingSensorName, "", audit.LevelInfo, "Resumed monitoring after restart...")
+		return m, tea.Batch(m.checkDeployStatus(), m.waitForPhaseChangedEvent(), refreshTickCmd(m.config.DashboardRefreshInterval))
+	case opjournal.KindUpgrade:
+		m.view = ViewUpgrading
+		m.upgrading = true
+		m.upgradingSensorName = entry.SensorName
+		m.upgradingSensorIP = entry.IP
+		m.upgradeStartTime = entry.StartTime
+		m.upgradeTargetVersion = entry.TargetVersion
+		m.audit.Seed("upgrade", m.upgradingSensorName, entry.LogTail)
+		m.upgradeLogs = m.logEvent("upgrade", m.upgradingSensorName, m.upgradingSensorIP, audit.LevelInfo, "Resumed monitoring after restart...")
+		return m, tea.Batch(m.checkUpgradeProgress(entry.IP), refreshTickCmd(m.config.DashboardRefreshInterval))
+	default:
+		return m, nil
+	}
+}
+
+func (m Model) handleConfirmDeploy(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y":
+		// Start deployment
+		m.view = ViewDeploying
+		m.deploying = true
+		m.deployStartTime = time.Now()
+		m.deployPhaseStart = time.Now()
+		m.deployStatus = "creating"
+		m.deployPhase = deploy.PhaseWaitingForInstance
+		m.deployPhaseEvents = make(chan deploy.PhaseChangedEvent, 8)
+		m.deployLogs = []string{"Creating new sensor..."}
+		m.refreshPaused = false
+		return m, tea.Batch(
+			m.createSensor(),
+			m.spinner.Tick,
+			m.waitForPhaseChangedEvent(),
+			refreshTickCmd(m.config.DashboardRefreshInterval),
+		)
+	case "n", "N", "esc":
+		m.view = ViewHome
+	}
+	return m, nil
+}
+
+func (m Model) handleDeployingKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if !m.deploying {
+		// Deployment complete - any key returns home
+		m.view = ViewHome
+		return m, m.loadSensors()
+	}
+
+	switch msg.String() {
+	case "esc":
+		// Only detach the view - m.deploying stays true so the tickMsg
+		// handler keeps polling and the job's phase/logs keep advancing.
+		// It shows up under Background Jobs until it completes.
+		m.view = ViewHome
+		m.statusMessage = "Deployment continuing in background. See Background Jobs (b) for progress."
+	case "p":
+		return m.toggleRefreshPause()
+	}
+	return m, nil
+}
+
+func (m Model) handleUpgradeKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "1":
+		// Upgrade to latest
+		if len(m.upgradeAvailableVersions) == 0 {
+			m.errorMessage = "No updates available via corelight-client"
+			return m, nil
+		}
+		m.upgradeOption = 1
+		m.upgradeTargetVersion = "latest"
+		m.view = ViewUpgradeConfirm
+	case "2":
+		// Upgrade to specific version - for now, prompt in confirm view
+		m.upgradeOption = 2
+		m.upgradeTargetVersion = "" // Will be set in confirm view
+		m.view = ViewUpgradeConfirm
+	case "3", "b", "esc":
+		m.view = ViewOperations
+	}
+	return m, nil
+}
+
+func (m Model) handleUpgradeConfirmKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y":
+		if m.selectedIdx >= len(m.sensors) {
+			m.view = ViewOperations
+			return m, nil
+		}
+		sensor := m.sensors[m.selectedIdx]
+
+		// For specific version upgrade, use current version if not set
+		if m.upgradeOption == 2 && m.upgradeTargetVersion == "" {
+			m.upgradeTargetVersion = m.upgradeCurrentVersion
+		}
+
+		// Start the upgrade
+		m.view = ViewUpgrading
+		m.upgrading = true
+		m.upgradingSensorName = sensor.Name
+		m.upgradingSensorIP = sensor.IP
 		m.upgradeStartTime = time.Now()
-		
+
+		m.audit.Seed("upgrade", m.upgradingSensorName, nil)
 		if m.upgradeOption == 1 {
-			m.upgradeLogs = []string{"Starting upgrade to latest version...", "Press ESC to exit monitoring..."}
+			m.upgradeLogs = m.logEvent("upgrade", m.upgradingSensorName, m.upgradingSensorIP, audit.LevelInfo, "Starting upgrade to latest version...")
 		} else {
-			m.upgradeLogs = []string{fmt.Sprintf("Starting upgrade to version %s...", m.upgradeTargetVersion), "Press ESC to exit monitoring..."}
+			m.upgradeLogs = m.logEvent("upgrade", m.upgradingSensorName, m.upgradingSensorIP, audit.LevelInfo, fmt.Sprintf("Starting upgrade to version %s...", m.upgradeTargetVersion))
 		}
-		
+		m.upgradeLogs = m.logEvent("upgrade", m.upgradingSensorName, m.upgradingSensorIP, audit.LevelInfo, "Press ESC to exit monitoring...")
+
+		m.journal.Put(opjournal.Entry{
+			SensorName:    m.upgradingSensorName,
+			IP:            sensor.IP,
+			Kind:          opjournal.KindUpgrade,
+			StartTime:     m.upgradeStartTime,
+			TargetVersion: m.upgradeTargetVersion,
+			LogTail:       m.upgradeLogs,
+		})
+		m.jobManager.Update(jobs.Snapshot{
+			ID:         jobs.ID(jobs.KindUpgrade, m.upgradingSensorName),
+			SensorName: m.upgradingSensorName,
+			SensorIP:   sensor.IP,
+			Kind:       jobs.KindUpgrade,
+			Status:     jobs.StatusRunning,
+			StartTime:  m.upgradeStartTime,
+			Phase:      "Starting upgrade",
+			LogTail:    m.upgradeLogs,
+		})
+
+		m.refreshPaused = false
 		return m, tea.Batch(
 			m.runUpgrade(sensor.IP),
 			m.spinner.Tick,
+			refreshTickCmd(m.config.DashboardRefreshInterval),
 		)
 	case "n", "N", "esc":
 		m.view = ViewUpgrade
@@ -957,9 +2027,12 @@ func (m Model) handleUpgradingKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	switch msg.String() {
 	case "esc":
-		m.upgrading = false
+		// Only detach the view - m.upgrading stays true so the tickMsg
+		// handler keeps polling m.upgradingSensorIP.
 		m.view = ViewOperations
-		m.statusMessage = "Upgrade continuing in background."
+		m.statusMessage = "Upgrade continuing in background. See Background Jobs (b) for progress."
+	case "p":
+		return m.toggleRefreshPause()
 	}
 	return m, nil
 }
@@ -973,12 +2046,14 @@ func (m Model) handleEnableFeaturesKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 		sensor := m.sensors[m.selectedIdx]
-		
+
 		// Start enabling features
 		m.view = ViewEnablingFeatures
 		m.enablingFeatures = true
+		m.enableFeaturesSensorName = sensor.Name
 		m.enableFeaturesStart = time.Now()
-		m.enableFeaturesLogs = []string{
+		m.audit.Seed("enable_features", m.enableFeaturesSensorName, nil)
+		for _, line := range []string{
 			fmt.Sprintf("Connecting to %s...", sensor.IP),
 			"Running enable_sensor_features.sh...",
 			"",
@@ -988,11 +2063,25 @@ func (m Model) handleEnableFeaturesKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			"  • Suricata IDS",
 			"  • SmartPCAP",
 			"",
+		} {
+			m.enableFeaturesLogs = m.logEvent("enable_features", m.enableFeaturesSensorName, sensor.IP, audit.LevelInfo, line)
 		}
-		
+		m.jobManager.Update(jobs.Snapshot{
+			ID:         jobs.ID(jobs.KindEnableFeatures, sensor.Name),
+			SensorName: sensor.Name,
+			SensorIP:   sensor.IP,
+			Kind:       jobs.KindEnableFeatures,
+			Status:     jobs.StatusRunning,
+			StartTime:  m.enableFeaturesStart,
+			Phase:      "Running enable_sensor_features.sh",
+			LogTail:    m.enableFeaturesLogs,
+		})
+
+		m.refreshPaused = false
 		return m, tea.Batch(
 			m.runEnableFeatures(sensor.IP),
 			m.spinner.Tick,
+			refreshTickCmd(m.config.DashboardRefreshInterval),
 		)
 	case "n", "N", "esc":
 		m.view = ViewOperations
@@ -1009,9 +2098,12 @@ func (m Model) handleEnablingFeaturesKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	switch msg.String() {
 	case "esc":
-		m.enablingFeatures = false
+		// Only detach the view - the runEnableFeatures tea.Cmd already in
+		// flight keeps running and will still deliver its result message.
 		m.view = ViewOperations
-		m.statusMessage = "Enable features continuing in background."
+		m.statusMessage = "Enable features continuing in background. See Background Jobs (b) for progress."
+	case "p":
+		return m.toggleRefreshPause()
 	}
 	return m, nil
 }
@@ -1025,20 +2117,36 @@ func (m Model) handleFleetManagerKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 		sensor := m.sensors[m.selectedIdx]
-		
+
 		// Start adding to fleet
 		m.view = ViewAddingToFleet
 		m.addingToFleet = true
+		m.fleetSensorName = sensor.Name
 		m.fleetStart = time.Now()
-		m.fleetLogs = []string{
+		m.audit.Seed("add_to_fleet", m.fleetSensorName, nil)
+		for _, line := range []string{
 			fmt.Sprintf("Connecting to %s...", sensor.IP),
 			"Running prepare_p1_automation.sh...",
 			"",
+		} {
+			m.fleetLogs = m.logEvent("add_to_fleet", m.fleetSensorName, sensor.IP, audit.LevelInfo, line)
 		}
-		
+		m.jobManager.Update(jobs.Snapshot{
+			ID:         jobs.ID(jobs.KindAddToFleet, sensor.Name),
+			SensorName: sensor.Name,
+			SensorIP:   sensor.IP,
+			Kind:       jobs.KindAddToFleet,
+			Status:     jobs.StatusRunning,
+			StartTime:  m.fleetStart,
+			Phase:      "Running prepare_p1_automation.sh",
+			LogTail:    m.fleetLogs,
+		})
+
+		m.refreshPaused = false
 		return m, tea.Batch(
 			m.runAddToFleet(sensor.IP),
 			m.spinner.Tick,
+			refreshTickCmd(m.config.DashboardRefreshInterval),
 		)
 	case "n", "N", "esc":
 		m.view = ViewOperations
@@ -1055,9 +2163,12 @@ func (m Model) handleAddingToFleetKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	switch msg.String() {
 	case "esc":
-		m.addingToFleet = false
+		// Only detach the view - the runAddToFleet tea.Cmd already in
+		// flight keeps running and will still deliver its result message.
 		m.view = ViewOperations
-		m.statusMessage = "Fleet registration continuing in background."
+		m.statusMessage = "Fleet registration continuing in background. See Background Jobs (b) for progress."
+	case "p":
+		return m.toggleRefreshPause()
 	}
 	return m, nil
 }
@@ -1066,21 +2177,48 @@ func (m Model) handleAddingToFleetKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 func (m Model) handleTrafficGeneratorKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "1":
-		// Configure sensor as traffic generator
+		// Configure sensor as traffic generator, installing every tool any
+		// shipped or custom profile might need (hping3, curl-loader,
+		// tcpreplay, ...) so picking a profile in step 2 never hits a
+		// missing binary.
 		if m.selectedIdx < len(m.sensors) {
 			sensor := m.sensors[m.selectedIdx]
+			profiles, err := traffic.ListProfiles()
+			if err != nil {
+				m.errorMessage = fmt.Sprintf("Cannot list traffic profiles: %v", err)
+				return m, nil
+			}
+			seen := map[string]bool{}
+			var tools []string
+			for _, p := range profiles {
+				for _, tool := range p.Tools {
+					if !seen[tool] {
+						seen[tool] = true
+						tools = append(tools, tool)
+					}
+				}
+			}
 			m.loading = true
 			m.loadingMsg = "Configuring traffic generator..."
 			return m, tea.Batch(
-				m.runConfigureTrafficGenerator(sensor.IP),
+				m.runConfigureTrafficGenerator(sensor.IP, tools),
 				m.spinner.Tick,
 			)
 		}
 	case "2":
-		// Start traffic generation - go to input view
-		m.trafficInputStep = 0
-		m.trafficTargetIP = ""
-		m.view = ViewTrafficStart
+		// Start traffic generation - pick a profile first
+		profiles, err := traffic.ListProfiles()
+		if err != nil {
+			m.errorMessage = fmt.Sprintf("Cannot list traffic profiles: %v", err)
+			return m, nil
+		}
+		if len(profiles) == 0 {
+			m.errorMessage = "No traffic profiles available"
+			return m, nil
+		}
+		m.trafficProfiles = profiles
+		m.trafficProfileIdx = 0
+		m.view = ViewTrafficProfile
 	case "3":
 		// Stop traffic generation
 		if m.selectedIdx < len(m.sensors) {
@@ -1099,115 +2237,267 @@ func (m Model) handleTrafficGeneratorKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				m.statusMessage = "Traffic: " + status
 			}
 		}
-	case "5", "b", "esc":
+	case "5":
+		// Saved quick profiles - a constant/burst/ramp descriptor saved to
+		// traffic.GeneratorProfilesPath, started directly without walking
+		// the ProfileSpec param wizard above.
+		profiles, err := traffic.LoadGeneratorProfiles()
+		if err != nil {
+			m.errorMessage = fmt.Sprintf("Cannot load saved profiles: %v", err)
+			return m, nil
+		}
+		m.trafficGenProfiles = profiles
+		m.trafficGenProfileIdx = 0
+		m.view = ViewTrafficGenProfile
+	case "6", "b", "esc":
 		m.view = ViewOperations
 	}
 	return m, nil
 }
 
+// handleTrafficProfileKeys drives the profile picker - the same up/down/
+// enter cursor pattern handleTrafficScenarioKeys uses over
+// traffic.ListScenarios(), here over traffic.ListProfiles().
+func (m Model) handleTrafficProfileKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if m.trafficProfileIdx > 0 {
+			m.trafficProfileIdx--
+		}
+	case "down", "j":
+		if m.trafficProfileIdx < len(m.trafficProfiles)-1 {
+			m.trafficProfileIdx++
+		}
+	case "enter":
+		if m.trafficProfileIdx >= len(m.trafficProfiles) {
+			return m, nil
+		}
+		m.trafficProfile = m.trafficProfiles[m.trafficProfileIdx]
+		m.trafficParamValues = map[string]string{}
+		m.trafficParamStep = 0
+		m.view = ViewTrafficStart
+	case "b", "esc":
+		m.view = ViewTrafficGenerator
+	}
+	return m, nil
+}
+
+// currentTrafficParam returns the ParamSpec the input view is currently
+// collecting, or false once every field has a value.
+func (m Model) currentTrafficParam() (traffic.ParamSpec, bool) {
+	if m.trafficParamStep >= len(m.trafficProfile.Params) {
+		return traffic.ParamSpec{}, false
+	}
+	return m.trafficProfile.Params[m.trafficParamStep], true
+}
+
 func (m Model) handleTrafficStartKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	key := msg.String()
-	
-	switch key {
-	case "esc":
+
+	param, collecting := m.currentTrafficParam()
+	if !collecting {
+		// Every field is filled in; enter launches the profile, anything
+		// else cancels back to the picker.
+		if key == "enter" {
+			return m.startTrafficProfile()
+		}
 		m.view = ViewTrafficGenerator
 		return m, nil
+	}
+
+	switch key {
+	case "esc":
+		m.view = ViewTrafficProfile
 	case "enter":
-		// Move to next step or start traffic
-		switch m.trafficInputStep {
-		case 0:
-			// Validate IP
-			if m.trafficTargetIP == "" {
-				m.errorMessage = "Target IP is required"
-				return m, nil
-			}
-			m.trafficInputStep = 1
-		case 1:
-			if m.trafficTargetPort == "" {
-				m.trafficTargetPort = "5555"
-			}
-			m.trafficInputStep = 2
-		case 2:
-			if m.trafficProtocol == "" {
-				m.trafficProtocol = "udp"
-			}
-			m.trafficInputStep = 3
-		case 3:
-			if m.trafficPPS == "" {
-				m.trafficPPS = "1000"
-			}
-			m.trafficInputStep = 4
-		case 4:
-			if m.trafficDuration == "" {
-				m.trafficDuration = "0"
-			}
-			// All inputs collected, start traffic
-			if m.selectedIdx < len(m.sensors) {
-				sensor := m.sensors[m.selectedIdx]
-				go m.sshClient.StartTrafficGeneration(
-					sensor.IP,
-					m.trafficTargetIP,
-					m.trafficTargetPort,
-					m.trafficProtocol,
-					m.trafficPPS,
-					m.trafficDuration,
-				)
-				m.statusMessage = fmt.Sprintf("Traffic generation started to %s:%s", m.trafficTargetIP, m.trafficTargetPort)
-			}
-			m.view = ViewTrafficGenerator
+		if m.trafficParamValues[param.Name] == "" && param.Default != "" {
+			m.trafficParamValues[param.Name] = param.Default
 		}
+		if m.trafficParamValues[param.Name] == "" && param.Required {
+			m.errorMessage = fmt.Sprintf("%s is required", param.Label)
+			return m, nil
+		}
+		m.trafficParamStep++
 	case "backspace":
-		// Handle backspace for current input
-		switch m.trafficInputStep {
-		case 0:
-			if len(m.trafficTargetIP) > 0 {
-				m.trafficTargetIP = m.trafficTargetIP[:len(m.trafficTargetIP)-1]
-			}
-		case 1:
-			if len(m.trafficTargetPort) > 0 {
-				m.trafficTargetPort = m.trafficTargetPort[:len(m.trafficTargetPort)-1]
-			}
-		case 2:
-			if len(m.trafficProtocol) > 0 {
-				m.trafficProtocol = m.trafficProtocol[:len(m.trafficProtocol)-1]
-			}
-		case 3:
-			if len(m.trafficPPS) > 0 {
-				m.trafficPPS = m.trafficPPS[:len(m.trafficPPS)-1]
-			}
-		case 4:
-			if len(m.trafficDuration) > 0 {
-				m.trafficDuration = m.trafficDuration[:len(m.trafficDuration)-1]
-			}
+		v := m.trafficParamValues[param.Name]
+		if len(v) > 0 {
+			m.trafficParamValues[param.Name] = v[:len(v)-1]
 		}
 	default:
-		// Add character to current input
 		if len(key) == 1 {
-			switch m.trafficInputStep {
-			case 0:
-				if (key >= "0" && key <= "9") || key == "." {
-					m.trafficTargetIP += key
-				}
-			case 1:
+			switch param.Kind {
+			case "int":
 				if key >= "0" && key <= "9" {
-					m.trafficTargetPort += key
+					m.trafficParamValues[param.Name] += key
 				}
-			case 2:
-				m.trafficProtocol += key
-			case 3:
-				if key >= "0" && key <= "9" {
-					m.trafficPPS += key
+			case "float":
+				if (key >= "0" && key <= "9") || key == "." {
+					m.trafficParamValues[param.Name] += key
 				}
-			case 4:
+			default:
+				m.trafficParamValues[param.Name] += key
+			}
+		}
+	}
+	return m, nil
+}
+
+// startTrafficProfile launches m.trafficProfile against the selected
+// sensor with the values collected into m.trafficParamValues. pcap-replay
+// is driven through UploadPCAP/ReplayPCAP since it needs to push a local
+// file first; every other profile resolves to a single shell command run
+// via RunProfileCommand, the same background-and-forget style
+// StartTrafficGeneration has always used.
+func (m Model) startTrafficProfile() (tea.Model, tea.Cmd) {
+	if m.selectedIdx >= len(m.sensors) {
+		m.view = ViewTrafficGenerator
+		return m, nil
+	}
+	sensor := m.sensors[m.selectedIdx]
+	profile := m.trafficProfile
+	values := m.trafficParamValues
+
+	if profile.IsPCAPReplay() {
+		pcapPath := values["pcap_path"]
+		speed, _ := strconv.ParseFloat(values["speed"], 64)
+		loop, _ := strconv.Atoi(values["loop"])
+		go func() {
+			remotePath, err := m.sshClient.UploadPCAP(sensor.IP, pcapPath)
+			if err != nil {
+				return
+			}
+			m.sshClient.ReplayPCAP(sensor.IP, remotePath, speed, loop)
+		}()
+		m.statusMessage = fmt.Sprintf("Replaying %s on %s", pcapPath, sensor.IP)
+		m.view = ViewTrafficGenerator
+		return m, nil
+	}
+
+	cmd, err := profile.Command(values)
+	if err != nil {
+		m.errorMessage = err.Error()
+		return m, nil
+	}
+	go m.sshClient.RunProfileCommand(sensor.IP, cmd)
+	m.statusMessage = fmt.Sprintf("Started %s on %s", profile.Name, sensor.IP)
+	m.view = ViewTrafficGenerator
+	return m, nil
+}
+
+// handleTrafficGenProfileKeys drives the saved-quick-profile picker: up/down/
+// enter over m.trafficGenProfiles, with one extra row past the end for
+// "New quick profile" that jumps to ViewTrafficGenProfileNew.
+func (m Model) handleTrafficGenProfileKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if m.trafficGenProfileIdx > 0 {
+			m.trafficGenProfileIdx--
+		}
+	case "down", "j":
+		if m.trafficGenProfileIdx < len(m.trafficGenProfiles) {
+			m.trafficGenProfileIdx++
+		}
+	case "enter":
+		if m.trafficGenProfileIdx >= len(m.trafficGenProfiles) {
+			m.trafficParamValues = map[string]string{}
+			m.trafficParamStep = 0
+			m.view = ViewTrafficGenProfileNew
+			return m, nil
+		}
+		if m.selectedIdx >= len(m.sensors) {
+			return m, nil
+		}
+		profile := m.trafficGenProfiles[m.trafficGenProfileIdx]
+		sensor := m.sensors[m.selectedIdx]
+		descriptor, err := profile.Descriptor()
+		if err != nil {
+			m.errorMessage = err.Error()
+			return m, nil
+		}
+		go m.sshClient.StartProfile(sensor.IP, descriptor)
+		m.statusMessage = fmt.Sprintf("Started %s on %s", profile.Name, sensor.IP)
+		m.view = ViewTrafficGenerator
+	case "b", "esc":
+		m.view = ViewTrafficGenerator
+	}
+	return m, nil
+}
+
+// handleTrafficGenProfileNewKeys collects traffic.QuickProfileParams into
+// m.trafficParamValues via the same per-Kind character rules
+// handleTrafficStartKeys uses, then saves and starts the resulting
+// constant-rate GeneratorProfile once every field is filled in.
+func (m Model) handleTrafficGenProfileNewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+	if m.trafficParamStep >= len(traffic.QuickProfileParams) {
+		if key == "enter" {
+			return m.saveAndStartGeneratorProfile()
+		}
+		m.view = ViewTrafficGenProfile
+		return m, nil
+	}
+	param := traffic.QuickProfileParams[m.trafficParamStep]
+
+	switch key {
+	case "esc":
+		m.view = ViewTrafficGenProfile
+	case "enter":
+		if m.trafficParamValues[param.Name] == "" && param.Default != "" {
+			m.trafficParamValues[param.Name] = param.Default
+		}
+		if m.trafficParamValues[param.Name] == "" && param.Required {
+			m.errorMessage = fmt.Sprintf("%s is required", param.Label)
+			return m, nil
+		}
+		m.trafficParamStep++
+	case "backspace":
+		v := m.trafficParamValues[param.Name]
+		if len(v) > 0 {
+			m.trafficParamValues[param.Name] = v[:len(v)-1]
+		}
+	default:
+		if len(key) == 1 {
+			switch param.Kind {
+			case "int":
 				if key >= "0" && key <= "9" {
-					m.trafficDuration += key
+					m.trafficParamValues[param.Name] += key
 				}
+			default:
+				m.trafficParamValues[param.Name] += key
 			}
 		}
 	}
 	return m, nil
 }
 
+// saveAndStartGeneratorProfile persists the values collected into
+// m.trafficParamValues as a new GeneratorProfile and starts it on the
+// selected sensor, mirroring startTrafficProfile's fire-and-forget style.
+func (m Model) saveAndStartGeneratorProfile() (tea.Model, tea.Cmd) {
+	profile, err := traffic.GeneratorProfileFromValues(m.trafficParamValues)
+	if err != nil {
+		m.errorMessage = err.Error()
+		return m, nil
+	}
+	if err := traffic.SaveGeneratorProfile(profile); err != nil {
+		m.errorMessage = fmt.Sprintf("save profile: %v", err)
+		return m, nil
+	}
+	if m.selectedIdx >= len(m.sensors) {
+		m.view = ViewTrafficGenerator
+		return m, nil
+	}
+	sensor := m.sensors[m.selectedIdx]
+	descriptor, err := profile.Descriptor()
+	if err != nil {
+		m.errorMessage = err.Error()
+		return m, nil
+	}
+	go m.sshClient.StartProfile(sensor.IP, descriptor)
+	m.statusMessage = fmt.Sprintf("Saved and started %s on %s", profile.Name, sensor.IP)
+	m.view = ViewTrafficGenerator
+	return m, nil
+}
+
 // Rendering functions
 func (m Model) renderLoading() string {
 	return fmt.Sprintf("\n\n  %s %s\n", m.spinner.View(), m.loadingMsg)
@@ -1218,7 +2508,7 @@ func (m Model) renderHome() string {
 
 	// Header
 	b.WriteString("\n")
-	b.WriteString(ui.RenderHeader(m.styles, "EC2 SENSOR MANAGER", "v2.0"))
+	b.WriteString(ui.RenderMainHeader(m.styles, m.width, "EC2 SENSOR MANAGER", "v2.0", m.config.BannerFile))
 	b.WriteString("\n")
 
 	// Breadcrumb
@@ -1261,12 +2551,14 @@ func (m Model) renderHome() string {
 	b.WriteString(ui.RenderSection(m.styles, "Options"))
 	b.WriteString("\n")
 	b.WriteString(ui.RenderMenuItem(m.styles, "n", "Deploy NEW sensor", "Create and configure new sensor (~20 min)", false))
+	b.WriteString("\n")
+	b.WriteString(ui.RenderMenuItem(m.styles, "b", "Background Jobs", "View/re-attach to running and past operations", false))
 	b.WriteString("\n\n")
 
 	// Shortcuts
 	b.WriteString(ui.RenderSection(m.styles, "Shortcuts"))
 	b.WriteString("\n")
-	b.WriteString(ui.RenderShortcuts(m.styles, ui.MainShortcuts()))
+	b.WriteString(ui.RenderShortcuts(m.styles, ui.MainShortcuts(m.keymap)))
 	b.WriteString("\n")
 
 	return b.String()
@@ -1332,12 +2624,19 @@ func (m Model) renderOperations() string {
 	b.WriteString(ui.RenderMenuItem(m.styles, "7", "Health Dashboard", "Detailed health & service view", false))
 	b.WriteString("\n")
 	b.WriteString(ui.RenderMenuItem(m.styles, "8", "Back to sensor list", "", false))
-	b.WriteString("\n\n")
+	b.WriteString("\n")
+	b.WriteString(ui.RenderMenuItem(m.styles, "s", "Run traffic scenario", "Scripted multi-stage traffic test with pass/fail checks", false))
+	b.WriteString("\n")
+	if _, ok := m.resumableOps[sensor.Name]; ok {
+		b.WriteString(ui.RenderMenuItem(m.styles, "9", "Attach to running operation", "Resume monitoring a journaled deploy/upgrade", false))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
 
 	// Shortcuts
 	b.WriteString(ui.RenderSection(m.styles, "Shortcuts"))
 	b.WriteString("\n")
-	b.WriteString(ui.RenderShortcuts(m.styles, ui.OperationsShortcuts()))
+	b.WriteString(ui.RenderShortcuts(m.styles, ui.OperationsShortcuts(m.keymap)))
 	b.WriteString("\n")
 
 	return b.String()
@@ -1399,7 +2698,11 @@ func (m Model) renderHealth() string {
 	}
 
 	b.WriteString("\n")
-	b.WriteString(m.styles.Help.Render("  Press b or Enter to return..."))
+	if m.refreshPaused {
+		b.WriteString(m.styles.Help.Render("  Press b or Enter to return, p to resume refresh..."))
+	} else {
+		b.WriteString(m.styles.Help.Render("  Press b or Enter to return, p to pause refresh..."))
+	}
 	b.WriteString("\n")
 
 	return b.String()
@@ -1432,6 +2735,34 @@ func (m Model) renderConfirmDelete() string {
 	return b.String()
 }
 
+func (m Model) renderConfirmBatch() string {
+	var b strings.Builder
+
+	b.WriteString("\n")
+	b.WriteString(ui.RenderHeader(m.styles, fmt.Sprintf("CONFIRM BATCH %s", strings.ToUpper(m.batchOp)), ""))
+	b.WriteString("\n\n")
+
+	if m.batchOp == "delete" {
+		b.WriteString(ui.RenderMessage(m.styles, "warning", "This action cannot be undone!", ""))
+		b.WriteString("\n\n")
+	}
+
+	b.WriteString(ui.RenderSection(m.styles, fmt.Sprintf("Sensors (%d)", len(m.batchSensors))))
+	b.WriteString("\n")
+	for _, sensor := range m.batchSensors {
+		b.WriteString(fmt.Sprintf("  %s  %s\n", sensor.ShortID(), sensor.IP))
+	}
+	b.WriteString("\n")
+
+	b.WriteString(fmt.Sprintf("  %s %d sensors? %s / %s\n",
+		strings.ToUpper(m.batchOp[:1])+m.batchOp[1:],
+		len(m.batchSensors),
+		m.styles.Success.Render("[y]es"),
+		m.styles.Error.Render("[n]o")))
+
+	return b.String()
+}
+
 func (m Model) renderConfirmDeploy() string {
 	var b strings.Builder
 
@@ -1482,31 +2813,39 @@ func (m Model) renderDeploying() string {
 	// Phase progress indicator
 	b.WriteString(ui.RenderSection(m.styles, "Deployment Progress"))
 	b.WriteString("\n")
-	
-	// Show phase progress
-	phases := []struct {
-		num  int
-		name string
-	}{
-		{1, "SSH Port"},
-		{2, "SSH Service"},
-		{3, "Seeding (60+ min)"},
-	}
-	
-	for _, p := range phases {
+
+	// Show phase progress - iterate deploy.All() rather than a hard-coded
+	// slice, skipping the bookend phases (waiting for the instance to
+	// exist, and done) since those are covered by the status indicator
+	// below instead of a checklist row.
+	deployPhaseNames := map[deploy.Phase]string{
+		deploy.PhaseSSHPort:         "SSH Port",
+		deploy.PhaseSSHService:      "SSH Service",
+		deploy.PhaseSeeding:         "Seeding (60+ min)",
+		deploy.PhaseFeaturesEnabled: "Features Enabled",
+	}
+	var phases []deploy.Phase
+	for _, p := range deploy.All() {
+		if p == deploy.PhaseWaitingForInstance || p == deploy.PhaseComplete {
+			continue
+		}
+		phases = append(phases, p)
+	}
+
+	for i, p := range phases {
 		var icon, style string
-		if m.deployPhase > p.num {
+		if m.deployPhase > p {
 			icon = "✓"
 			style = "success"
-		} else if m.deployPhase == p.num {
+		} else if m.deployPhase == p {
 			icon = m.spinner.View()
 			style = "info"
 		} else {
 			icon = "○"
 			style = "help"
 		}
-		
-		line := fmt.Sprintf("  %s Phase %d: %s", icon, p.num, p.name)
+
+		line := fmt.Sprintf("  %s Phase %d: %s", icon, i+1, deployPhaseNames[p])
 		switch style {
 		case "success":
 			b.WriteString(m.styles.Success.Render(line))
@@ -1521,9 +2860,9 @@ func (m Model) renderDeploying() string {
 
 	// Status indicator
 	if m.deploying {
-		if m.deployPhase == 0 {
+		if m.deployPhase == deploy.PhaseWaitingForInstance {
 			b.WriteString(fmt.Sprintf("  %s Waiting for sensor to start...\n", m.spinner.View()))
-		} else if m.deployPhase == 3 {
+		} else if m.deployPhase == deploy.PhaseSeeding {
 			b.WriteString(fmt.Sprintf("  %s Seeding in progress (this takes 60+ minutes)...\n", m.spinner.View()))
 		} else {
 			b.WriteString(fmt.Sprintf("  %s Deployment in progress...\n", m.spinner.View()))
@@ -1564,6 +2903,256 @@ func (m Model) renderDeploying() string {
 	return b.String()
 }
 
+func (m Model) renderBatchOperations() string {
+	var b strings.Builder
+
+	b.WriteString("\n")
+	b.WriteString(ui.RenderHeader(m.styles, fmt.Sprintf("BATCH %s", strings.ToUpper(m.batchOp)), ""))
+	b.WriteString("\n\n")
+
+	b.WriteString(ui.RenderSection(m.styles, "Progress"))
+	b.WriteString("\n")
+	for _, name := range m.batchOrder {
+		state := m.batchStates[name]
+		if state == nil {
+			continue
+		}
+
+		elapsed := formatElapsed(time.Since(state.started).Round(time.Second))
+
+		var icon, line string
+		switch state.phase {
+		case batch.PhaseDone:
+			icon = "✓"
+			line = fmt.Sprintf("  %s %s - done (%s)", icon, name, elapsed)
+			b.WriteString(m.styles.Success.Render(line))
+		case batch.PhaseErr:
+			icon = "✗"
+			line = fmt.Sprintf("  %s %s - %s (%s)", icon, name, state.err, elapsed)
+			b.WriteString(m.styles.Error.Render(line))
+		case batch.PhaseRetrying:
+			icon = "↻"
+			line = fmt.Sprintf("  %s %s - %s (%s)", icon, name, state.message, elapsed)
+			b.WriteString(m.styles.Warning.Render(line))
+		default:
+			icon = m.spinner.View()
+			msg := state.message
+			if msg == "" {
+				msg = "waiting"
+			}
+			line = fmt.Sprintf("  %s %s - %s (%s)", icon, name, msg, elapsed)
+			b.WriteString(m.styles.Info.Render(line))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	if !m.batchActive && len(m.batchOrder) > 0 {
+		succeeded, failed := 0, 0
+		var failures []string
+		for _, name := range m.batchOrder {
+			state := m.batchStates[name]
+			if state == nil {
+				continue
+			}
+			if state.phase == batch.PhaseErr {
+				failed++
+				failures = append(failures, fmt.Sprintf("%s: %v", name, state.err))
+			} else {
+				succeeded++
+			}
+		}
+		b.WriteString(ui.RenderSection(m.styles, "Summary"))
+		b.WriteString("\n")
+		b.WriteString(fmt.Sprintf("  %d succeeded, %d failed\n", succeeded, failed))
+		for _, failure := range failures {
+			b.WriteString(m.styles.Error.Render("  - " + failure))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+
+	if m.batchActive {
+		b.WriteString(m.styles.Warning.Render("  ⚠ Press ESC or Ctrl-C to cancel"))
+	} else {
+		b.WriteString(m.styles.Help.Render("  Press any key to return home"))
+	}
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+func (m Model) renderTrafficScenario() string {
+	if m.selectedIdx >= len(m.sensors) {
+		return "No sensor selected"
+	}
+	sensor := m.sensors[m.selectedIdx]
+	var b strings.Builder
+
+	b.WriteString("\n")
+	b.WriteString(ui.RenderHeader(m.styles, "TRAFFIC SCENARIOS", sensor.ShortID()))
+	b.WriteString("\n")
+	b.WriteString(ui.RenderBreadcrumb(m.styles, "Home", "Sensors", sensor.ShortID(), "Scenarios"))
+	b.WriteString("\n\n")
+
+	if m.errorMessage != "" {
+		b.WriteString(ui.RenderMessage(m.styles, "error", m.errorMessage, ""))
+		b.WriteString("\n")
+	}
+
+	b.WriteString(ui.RenderSection(m.styles, fmt.Sprintf("Select a scenario (%s)", traffic.ScenariosDir())))
+	b.WriteString("\n")
+	for i, name := range m.trafficScenarios {
+		b.WriteString(ui.RenderMenuItem(m.styles, "", name, "", i == m.trafficScenarioIdx))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(m.styles.Help.Render("  Up/Down to choose, Enter to run, b/esc to go back"))
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+func (m Model) renderTrafficScenarioReport() string {
+	report := m.trafficScenarioReport
+	if report == nil {
+		return "No scenario report available"
+	}
+	var b strings.Builder
+
+	b.WriteString("\n")
+	b.WriteString(ui.RenderHeader(m.styles, "SCENARIO REPORT", report.Scenario))
+	b.WriteString("\n\n")
+
+	b.WriteString(ui.RenderKeyValue(m.styles, "Sensor", report.Sensor))
+	b.WriteString("\n")
+	if report.Passed {
+		b.WriteString(m.styles.Success.Render("  Overall: PASSED"))
+	} else {
+		b.WriteString(m.styles.Error.Render("  Overall: FAILED"))
+	}
+	b.WriteString("\n\n")
+
+	b.WriteString(ui.RenderSection(m.styles, "Stages"))
+	b.WriteString("\n")
+	for _, stage := range report.Stages {
+		if stage.Err != nil {
+			b.WriteString(m.styles.Error.Render(fmt.Sprintf("  ✗ %s - error: %v", stage.Stage, stage.Err)))
+			b.WriteString("\n")
+			continue
+		}
+		if stage.Passed {
+			b.WriteString(m.styles.Success.Render(fmt.Sprintf("  ✓ %s - passed (%s)", stage.Stage, stage.Duration.Round(time.Second))))
+		} else {
+			b.WriteString(m.styles.Error.Render(fmt.Sprintf("  ✗ %s - failed (%s)", stage.Stage, stage.Duration.Round(time.Second))))
+		}
+		b.WriteString("\n")
+		for _, failure := range stage.Failures {
+			b.WriteString(m.styles.Error.Render(fmt.Sprintf("      - %s", failure)))
+			b.WriteString("\n")
+		}
+	}
+	b.WriteString("\n")
+	b.WriteString(m.styles.Help.Render("  Press b, Esc or Enter to return to operations"))
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+// renderBackgroundJobs lists every job jobManager knows about, or (with
+// m.jobsDetailID set) one job's live phase and log tail.
+func (m Model) renderBackgroundJobs() string {
+	snaps := m.jobManager.List()
+	var b strings.Builder
+
+	b.WriteString("\n")
+	b.WriteString(ui.RenderHeader(m.styles, "BACKGROUND JOBS", ""))
+	b.WriteString("\n")
+	b.WriteString(ui.RenderBreadcrumb(m.styles, "Home", "Background Jobs"))
+	b.WriteString("\n\n")
+
+	if m.jobsDetailID != "" {
+		var snap jobs.Snapshot
+		found := false
+		for _, s := range snaps {
+			if s.ID == m.jobsDetailID {
+				snap, found = s, true
+				break
+			}
+		}
+		if !found {
+			b.WriteString(ui.RenderMessage(m.styles, "warning", "Job no longer tracked", ""))
+			b.WriteString("\n")
+			b.WriteString(m.styles.Help.Render("  b/esc to go back"))
+			return b.String()
+		}
+
+		b.WriteString(ui.RenderKeyValue(m.styles, "Sensor", snap.SensorName))
+		b.WriteString("\n")
+		b.WriteString(ui.RenderKeyValue(m.styles, "Kind", string(snap.Kind)))
+		b.WriteString("\n")
+		b.WriteString(ui.RenderKeyValue(m.styles, "Elapsed", formatElapsed(time.Since(snap.StartTime).Round(time.Second))))
+		b.WriteString("\n")
+		switch snap.Status {
+		case jobs.StatusRunning:
+			b.WriteString(fmt.Sprintf("  %s %s", m.spinner.View(), snap.Phase))
+		case jobs.StatusRetrying:
+			b.WriteString(m.styles.Warning.Render(fmt.Sprintf("  %s retrying (attempt %d/%d)...", m.spinner.View(), snap.Retries, jobs.MaxRetries)))
+		case jobs.StatusDone:
+			b.WriteString(m.styles.Success.Render(fmt.Sprintf("  ✓ %s", snap.Phase)))
+		case jobs.StatusFailed:
+			b.WriteString(m.styles.Error.Render(fmt.Sprintf("  ✗ Failed: %s", snap.Err)))
+		}
+		b.WriteString("\n\n")
+
+		b.WriteString(ui.RenderSection(m.styles, "Log"))
+		b.WriteString("\n")
+		for _, line := range snap.LogTail {
+			b.WriteString("  " + line)
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+		if snap.Status == jobs.StatusFailed && (snap.Kind == jobs.KindEnableFeatures || snap.Kind == jobs.KindAddToFleet) && snap.Retries < jobs.MaxRetries {
+			b.WriteString(m.styles.Help.Render("  b/esc/enter to go back, r to retry"))
+		} else if snap.Status == jobs.StatusRunning || snap.Status == jobs.StatusRetrying {
+			b.WriteString(m.styles.Help.Render("  b/esc/enter to go back, c to cancel"))
+		} else {
+			b.WriteString(m.styles.Help.Render("  b/esc/enter to go back"))
+		}
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	if len(snaps) == 0 {
+		b.WriteString(ui.RenderMessage(m.styles, "info", "No jobs recorded yet", ""))
+		b.WriteString("\n")
+		b.WriteString(m.styles.Help.Render("  b/esc to go back"))
+		return b.String()
+	}
+
+	b.WriteString(ui.RenderSection(m.styles, "Jobs"))
+	b.WriteString("\n")
+	for i, snap := range snaps {
+		var icon string
+		switch snap.Status {
+		case jobs.StatusRunning, jobs.StatusRetrying:
+			icon = m.spinner.View()
+		case jobs.StatusDone:
+			icon = "✓"
+		case jobs.StatusFailed:
+			icon = "✗"
+		}
+		label := fmt.Sprintf("%s  %s (%s) - %s", icon, snap.SensorName, snap.Kind, snap.Phase)
+		b.WriteString(ui.RenderMenuItem(m.styles, "", label, "", i == m.jobsCursor))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(m.styles.Help.Render("  Up/Down to choose, Enter for detail, c to cancel, r to retry, b/esc to go back"))
+	b.WriteString("\n")
+
+	return b.String()
+}
+
 func (m Model) renderUpgrade() string {
 	if m.selectedIdx >= len(m.sensors) {
 		return "No sensor selected"
@@ -1948,93 +3537,178 @@ func (m Model) renderTrafficGenerator() string {
 	b.WriteString("\n")
 	b.WriteString(ui.RenderMenuItem(m.styles, "4", "View traffic statistics", "Show active processes", false))
 	b.WriteString("\n")
-	b.WriteString(ui.RenderMenuItem(m.styles, "5", "Back to operations", "Return to operations menu", false))
+	b.WriteString(ui.RenderMenuItem(m.styles, "5", "Saved quick profiles", "Load or save a constant/burst/ramp profile", false))
+	b.WriteString("\n")
+	b.WriteString(ui.RenderMenuItem(m.styles, "6", "Back to operations", "Return to operations menu", false))
 	b.WriteString("\n\n")
 
-	b.WriteString(m.styles.Help.Render("  Select traffic operation [1-5]"))
+	b.WriteString(m.styles.Help.Render("  Select traffic operation [1-6]"))
 	b.WriteString("\n")
 
 	return b.String()
 }
 
+// renderTrafficProfile lists the available profiles (built-in plus any
+// custom *.yaml under traffic.ProfilesDir) for the user to pick from before
+// renderTrafficStart prompts for that profile's fields.
+func (m Model) renderTrafficProfile() string {
+	if m.selectedIdx >= len(m.sensors) {
+		return "No sensor selected"
+	}
+	sensor := m.sensors[m.selectedIdx]
+	var b strings.Builder
+
+	b.WriteString("\n")
+	b.WriteString(ui.RenderHeader(m.styles, "TRAFFIC PROFILES", sensor.IP))
+	b.WriteString("\n\n")
+
+	if m.errorMessage != "" {
+		b.WriteString(ui.RenderMessage(m.styles, "error", m.errorMessage, ""))
+		b.WriteString("\n")
+	}
+
+	b.WriteString(ui.RenderSection(m.styles, fmt.Sprintf("Select a profile (custom: %s)", traffic.ProfilesDir())))
+	b.WriteString("\n")
+	for i, p := range m.trafficProfiles {
+		b.WriteString(ui.RenderMenuItem(m.styles, "", p.Name, p.Description, i == m.trafficProfileIdx))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(m.styles.Help.Render("  Up/Down to choose, Enter to configure, b/esc to go back"))
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+// renderTrafficStart prompts for m.trafficProfile's declared Params one at
+// a time, rather than the fixed IP/port/protocol/pps/duration set every
+// profile used to share.
 func (m Model) renderTrafficStart() string {
 	if m.selectedIdx >= len(m.sensors) {
 		return "No sensor selected"
 	}
 
 	sensor := m.sensors[m.selectedIdx]
+	profile := m.trafficProfile
 	var b strings.Builder
 
 	b.WriteString("\n")
-	b.WriteString(ui.RenderHeader(m.styles, "START TRAFFIC", sensor.IP))
+	b.WriteString(ui.RenderHeader(m.styles, "START TRAFFIC: "+strings.ToUpper(profile.Name), sensor.IP))
 	b.WriteString("\n\n")
 
-	b.WriteString(ui.RenderSection(m.styles, "Traffic Configuration"))
+	b.WriteString(ui.RenderSection(m.styles, profile.Description))
 	b.WriteString("\n")
 
-	// Show inputs with current values
-	ipLabel := "Target IP:"
-	portLabel := "Target Port:"
-	protoLabel := "Protocol:"
-	ppsLabel := "Packets/sec:"
-	durLabel := "Duration (0=continuous):"
-
-	// Highlight current input
-	switch m.trafficInputStep {
-	case 0:
-		b.WriteString(m.styles.Info.Render(fmt.Sprintf("  > %s %s_", ipLabel, m.trafficTargetIP)))
-		b.WriteString("\n")
-		b.WriteString(m.styles.Help.Render(fmt.Sprintf("    %s %s", portLabel, m.trafficTargetPort)))
-		b.WriteString("\n")
-		b.WriteString(m.styles.Help.Render(fmt.Sprintf("    %s %s", protoLabel, m.trafficProtocol)))
-		b.WriteString("\n")
-		b.WriteString(m.styles.Help.Render(fmt.Sprintf("    %s %s", ppsLabel, m.trafficPPS)))
-		b.WriteString("\n")
-		b.WriteString(m.styles.Help.Render(fmt.Sprintf("    %s %s", durLabel, m.trafficDuration)))
-	case 1:
-		b.WriteString(m.styles.Success.Render(fmt.Sprintf("  ✓ %s %s", ipLabel, m.trafficTargetIP)))
-		b.WriteString("\n")
-		b.WriteString(m.styles.Info.Render(fmt.Sprintf("  > %s %s_", portLabel, m.trafficTargetPort)))
-		b.WriteString("\n")
-		b.WriteString(m.styles.Help.Render(fmt.Sprintf("    %s %s", protoLabel, m.trafficProtocol)))
-		b.WriteString("\n")
-		b.WriteString(m.styles.Help.Render(fmt.Sprintf("    %s %s", ppsLabel, m.trafficPPS)))
-		b.WriteString("\n")
-		b.WriteString(m.styles.Help.Render(fmt.Sprintf("    %s %s", durLabel, m.trafficDuration)))
-	case 2:
-		b.WriteString(m.styles.Success.Render(fmt.Sprintf("  ✓ %s %s", ipLabel, m.trafficTargetIP)))
-		b.WriteString("\n")
-		b.WriteString(m.styles.Success.Render(fmt.Sprintf("  ✓ %s %s", portLabel, m.trafficTargetPort)))
-		b.WriteString("\n")
-		b.WriteString(m.styles.Info.Render(fmt.Sprintf("  > %s %s_ (udp/tcp/http/mixed)", protoLabel, m.trafficProtocol)))
-		b.WriteString("\n")
-		b.WriteString(m.styles.Help.Render(fmt.Sprintf("    %s %s", ppsLabel, m.trafficPPS)))
-		b.WriteString("\n")
-		b.WriteString(m.styles.Help.Render(fmt.Sprintf("    %s %s", durLabel, m.trafficDuration)))
-	case 3:
-		b.WriteString(m.styles.Success.Render(fmt.Sprintf("  ✓ %s %s", ipLabel, m.trafficTargetIP)))
-		b.WriteString("\n")
-		b.WriteString(m.styles.Success.Render(fmt.Sprintf("  ✓ %s %s", portLabel, m.trafficTargetPort)))
-		b.WriteString("\n")
-		b.WriteString(m.styles.Success.Render(fmt.Sprintf("  ✓ %s %s", protoLabel, m.trafficProtocol)))
-		b.WriteString("\n")
-		b.WriteString(m.styles.Info.Render(fmt.Sprintf("  > %s %s_ (100-5000)", ppsLabel, m.trafficPPS)))
-		b.WriteString("\n")
-		b.WriteString(m.styles.Help.Render(fmt.Sprintf("    %s %s", durLabel, m.trafficDuration)))
-	case 4:
-		b.WriteString(m.styles.Success.Render(fmt.Sprintf("  ✓ %s %s", ipLabel, m.trafficTargetIP)))
+	for i, param := range profile.Params {
+		value := m.trafficParamValues[param.Name]
+		switch {
+		case i == m.trafficParamStep:
+			hint := ""
+			if param.Default != "" {
+				hint = fmt.Sprintf(" (default %s)", param.Default)
+			}
+			b.WriteString(m.styles.Info.Render(fmt.Sprintf("  > %s: %s_%s", param.Label, value, hint)))
+		case i < m.trafficParamStep:
+			b.WriteString(m.styles.Success.Render(fmt.Sprintf("  ✓ %s: %s", param.Label, value)))
+		default:
+			b.WriteString(m.styles.Help.Render(fmt.Sprintf("    %s:", param.Label)))
+		}
 		b.WriteString("\n")
-		b.WriteString(m.styles.Success.Render(fmt.Sprintf("  ✓ %s %s", portLabel, m.trafficTargetPort)))
+	}
+
+	b.WriteString("\n")
+	if m.errorMessage != "" {
+		b.WriteString(ui.RenderMessage(m.styles, "error", m.errorMessage, ""))
 		b.WriteString("\n")
-		b.WriteString(m.styles.Success.Render(fmt.Sprintf("  ✓ %s %s", protoLabel, m.trafficProtocol)))
+	}
+	if m.trafficParamStep >= len(profile.Params) {
+		b.WriteString(m.styles.Help.Render("  Enter: Start traffic | ESC: Cancel"))
+	} else {
+		b.WriteString(m.styles.Help.Render("  Enter: Next field | ESC: Back to profiles"))
+	}
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+// renderTrafficGenProfile lists saved GeneratorProfiles plus a trailing
+// "New quick profile" row, the same list-with-cursor pattern
+// renderTrafficProfile uses over ProfileSpecs.
+func (m Model) renderTrafficGenProfile() string {
+	if m.selectedIdx >= len(m.sensors) {
+		return "No sensor selected"
+	}
+	sensor := m.sensors[m.selectedIdx]
+	var b strings.Builder
+
+	b.WriteString("\n")
+	b.WriteString(ui.RenderHeader(m.styles, "SAVED QUICK PROFILES", sensor.IP))
+	b.WriteString("\n\n")
+
+	if m.errorMessage != "" {
+		b.WriteString(ui.RenderMessage(m.styles, "error", m.errorMessage, ""))
 		b.WriteString("\n")
-		b.WriteString(m.styles.Success.Render(fmt.Sprintf("  ✓ %s %s", ppsLabel, m.trafficPPS)))
+	}
+
+	b.WriteString(ui.RenderSection(m.styles, fmt.Sprintf("Select a profile (%s)", traffic.GeneratorProfilesPath())))
+	b.WriteString("\n")
+	for i, p := range m.trafficGenProfiles {
+		desc := fmt.Sprintf("%s %s:%d, %d pps", p.Pattern, p.Target, p.Port, p.PPS)
+		b.WriteString(ui.RenderMenuItem(m.styles, "", p.Name, desc, i == m.trafficGenProfileIdx))
 		b.WriteString("\n")
-		b.WriteString(m.styles.Info.Render(fmt.Sprintf("  > %s %s_ (seconds, 0=continuous)", durLabel, m.trafficDuration)))
 	}
+	b.WriteString(ui.RenderMenuItem(m.styles, "", "New quick profile...", "Build and save a constant-rate profile", m.trafficGenProfileIdx == len(m.trafficGenProfiles)))
+	b.WriteString("\n\n")
+	b.WriteString(m.styles.Help.Render("  Up/Down to choose, Enter to start/create, b/esc to go back"))
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+// renderTrafficGenProfileNew prompts for traffic.QuickProfileParams one at
+// a time, the same layout renderTrafficStart uses over a ProfileSpec's
+// Params.
+func (m Model) renderTrafficGenProfileNew() string {
+	if m.selectedIdx >= len(m.sensors) {
+		return "No sensor selected"
+	}
+	sensor := m.sensors[m.selectedIdx]
+	var b strings.Builder
+
+	b.WriteString("\n")
+	b.WriteString(ui.RenderHeader(m.styles, "NEW QUICK PROFILE", sensor.IP))
 	b.WriteString("\n\n")
 
-	b.WriteString(m.styles.Help.Render("  Enter: Next field | ESC: Cancel"))
+	b.WriteString(ui.RenderSection(m.styles, "Saved as a constant-rate traffic profile"))
+	b.WriteString("\n")
+
+	for i, param := range traffic.QuickProfileParams {
+		value := m.trafficParamValues[param.Name]
+		switch {
+		case i == m.trafficParamStep:
+			hint := ""
+			if param.Default != "" {
+				hint = fmt.Sprintf(" (default %s)", param.Default)
+			}
+			b.WriteString(m.styles.Info.Render(fmt.Sprintf("  > %s: %s_%s", param.Label, value, hint)))
+		case i < m.trafficParamStep:
+			b.WriteString(m.styles.Success.Render(fmt.Sprintf("  ✓ %s: %s", param.Label, value)))
+		default:
+			b.WriteString(m.styles.Help.Render(fmt.Sprintf("    %s:", param.Label)))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	if m.errorMessage != "" {
+		b.WriteString(ui.RenderMessage(m.styles, "error", m.errorMessage, ""))
+		b.WriteString("\n")
+	}
+	if m.trafficParamStep >= len(traffic.QuickProfileParams) {
+		b.WriteString(m.styles.Help.Render("  Enter: Save and start | ESC: Cancel"))
+	} else {
+		b.WriteString(m.styles.Help.Render("  Enter: Next field | ESC: Back to saved profiles"))
+	}
 	b.WriteString("\n")
 
 	return b.String()
@@ -2049,6 +3723,44 @@ func (m Model) helpContext() string {
 	}
 }
 
+// nextThemeOrStyleset advances the theme cycle: dark -> light -> minimal,
+// then through each user-defined styleset (alphabetically), then back to dark.
+func (m Model) nextThemeOrStyleset() (string, string) {
+	if m.stylesetName != "" {
+		stylesets := m.stylesetLoader.ListStylesets()
+		for i, name := range stylesets {
+			if name == m.stylesetName && i+1 < len(stylesets) {
+				return m.themeName, stylesets[i+1]
+			}
+		}
+		return "dark", ""
+	}
+
+	next := ui.NextTheme(m.themeName)
+	if next == "dark" && m.themeName != "dark" {
+		if stylesets := m.stylesetLoader.ListStylesets(); len(stylesets) > 0 {
+			return m.themeName, stylesets[0]
+		}
+	}
+	return next, ""
+}
+
+// buildStyles constructs the active Styles, applying the current styleset
+// (if any) on top of the current theme's built-in defaults. If the styleset
+// fails to load, it returns the plain theme styles and the load error.
+func (m Model) buildStyles() (ui.Styles, error) {
+	theme := ui.GetTheme(m.themeName)
+	styles := ui.NewStyles(theme, m.capabilities)
+	if m.stylesetName == "" {
+		return styles, nil
+	}
+	ss, err := m.stylesetLoader.Load(m.stylesetName, theme)
+	if err != nil {
+		return styles, fmt.Errorf("failed to load styleset %q: %w", m.stylesetName, err)
+	}
+	return ui.ApplyStyleset(styles, ss), nil
+}
+
 // Commands
 func tickCmd() tea.Cmd {
 	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
@@ -2056,6 +3768,14 @@ func tickCmd() tea.Cmd {
 	})
 }
 
+// refreshTickCmd schedules the next refreshSensorMsg for the
+// live-refreshing dashboard loop, d after now.
+func refreshTickCmd(d time.Duration) tea.Cmd {
+	return tea.Tick(d, func(t time.Time) tea.Msg {
+		return refreshSensorMsg(t)
+	})
+}
+
 func (m Model) loadSensors() tea.Cmd {
 	return func() tea.Msg {
 		// Read sensor names from .sensors file
@@ -2069,17 +3789,24 @@ func (m Model) loadSensors() tea.Cmd {
 			return extractNumericID(sensorNames[i]) < extractNumericID(sensorNames[j])
 		})
 
-		// Fetch each sensor from API
+		// Fetch all sensors concurrently so loading N sensors doesn't
+		// serialize N HTTP round trips, then put them back in sorted order.
+		fetched := make([]*models.Sensor, len(sensorNames))
+		for res := range m.apiClient.FetchSensorsStream(m.ctx, sensorNames) {
+			if res.Err == nil {
+				fetched[res.Index] = res.Sensor
+			}
+		}
+
 		var sensors []*models.Sensor
 		var deletedSensors []string
-		for _, name := range sensorNames {
-			sensor, err := m.apiClient.FetchSensor(name)
-			if err != nil {
+		for i, sensor := range fetched {
+			if sensor == nil {
 				continue // Skip sensors that fail to load
 			}
 			if sensor.Deleted {
 				// Track deleted sensors for cleanup
-				deletedSensors = append(deletedSensors, name)
+				deletedSensors = append(deletedSensors, sensorNames[i])
 			} else {
 				sensors = append(sensors, sensor)
 			}
@@ -2107,7 +3834,7 @@ func (m Model) collectMetrics(sensorIdx int, ip string) tea.Cmd {
 
 func (m Model) deleteSensor(sensorName string) tea.Cmd {
 	return func() tea.Msg {
-		err := m.apiClient.DeleteSensor(sensorName)
+		err := m.apiClient.DeleteSensor(m.ctx, sensorName)
 		if err == nil {
 			// Also update .sensors file
 			removeSensorFromFile(m.config.SensorsFile, sensorName)
@@ -2116,9 +3843,212 @@ func (m Model) deleteSensor(sensorName string) tea.Cmd {
 	}
 }
 
+// waitForBatchEvent blocks on the next batch.Event and wraps it as a
+// batchEventMsg; Update re-issues this itself after every event to keep
+// draining m.batchEvents until the channel closes.
+func (m Model) waitForBatchEvent() tea.Cmd {
+	ch := m.batchEvents
+	return func() tea.Msg {
+		event, ok := <-ch
+		return batchEventMsg{event: event, ok: ok}
+	}
+}
+
+// waitForPhaseChangedEvent blocks on the next deploy.PhaseChangedEvent off
+// m.deployPhaseEvents and wraps it as a phaseChangedMsg; Update re-issues
+// this itself after every event to keep draining the channel for the
+// lifetime of the current deploy.
+func (m Model) waitForPhaseChangedEvent() tea.Cmd {
+	ch := m.deployPhaseEvents
+	return func() tea.Msg {
+		event, ok := <-ch
+		return phaseChangedMsg{event: event, ok: ok}
+	}
+}
+
+// waitForSensorEvent blocks on the next api.Event off m.watcherEvents and
+// wraps it as a sensorEventMsg; Update re-issues this itself after every
+// event to keep draining the stream.
+func (m Model) waitForSensorEvent() tea.Cmd {
+	ch := m.watcherEvents
+	return func() tea.Msg {
+		event, ok := <-ch
+		return sensorEventMsg{event: event, ok: ok}
+	}
+}
+
+// waitForConfigReload blocks on the next config.ReloadResult off
+// m.configEvents and wraps it as a configReloadedMsg; Update re-issues this
+// itself after every event to keep draining the stream. If the watcher
+// failed to start, m.configEvents is nil and this simply never fires.
+func (m Model) waitForConfigReload() tea.Cmd {
+	ch := m.configEvents
+	return func() tea.Msg {
+		result, ok := <-ch
+		return configReloadedMsg{result: result, ok: ok}
+	}
+}
+
+// applyConfigReload swaps cfg in for m.config, rebuilding only the pieces
+// whose inputs actually changed: m.styles when the theme or styleset
+// changed, m.apiClient when the API endpoint/credentials/pool settings
+// changed, and m.sshClient when SSH credentials changed. Callers are
+// responsible for not invoking this while m.deploying || m.upgrading.
+func (m Model) applyConfigReload(cfg *config.Config) Model {
+	old := m.config
+
+	if cfg.Theme != old.Theme || cfg.StylesetPath != old.StylesetPath {
+		m.themeName = cfg.Theme
+		m.stylesetName = cfg.StylesetPath
+		m.stylesetLoader = stylesetLoaderFor(cfg)
+		if styles, err := m.buildStyles(); err != nil {
+			m.errorMessage = fmt.Sprintf("config reload: %v", err)
+		} else {
+			m.styles = styles
+		}
+	}
+
+	if cfg.APIBaseURL != old.APIBaseURL || cfg.APIKey != old.APIKey ||
+		cfg.APIPoolSize != old.APIPoolSize || cfg.APIRateLimit != old.APIRateLimit {
+		client := api.NewClient(cfg)
+		if m.metrics != nil {
+			client.SetMetrics(m.metrics)
+		}
+		m.apiClient = api.NewCachingClient(client, m.sensorStore, cfg.CacheTTL)
+	}
+
+	if cfg.SSHUsername != old.SSHUsername || cfg.SSHPassword != old.SSHPassword ||
+		cfg.SSHUseKeys != old.SSHUseKeys || cfg.SSHInsecureHostKey != old.SSHInsecureHostKey ||
+		cfg.SSHKnownHostsFile != old.SSHKnownHostsFile {
+		if m.sshClient != nil {
+			m.sshClient.Close()
+		}
+		sshClient := ssh.NewClient(cfg)
+		if m.metrics != nil {
+			sshClient.SetMetrics(m.metrics)
+			sshClient.WithSinks(ssh.NewPrometheusMetricsSink(m.metrics))
+		}
+		m.sshClient = sshClient
+	}
+
+	m.config = cfg
+	m.statusMessage = "Config reloaded from disk"
+	return m
+}
+
+// applySensorEvent mutates m.sensors in place for ev instead of replacing
+// the slice, so cursor position, expanded Metrics and Selected flags
+// survive the update. It returns the affected sensor's index, or -1 if ev
+// was a SensorDeleted for a sensor no longer present.
+func (m *Model) applySensorEvent(ev api.Event) int {
+	idx := -1
+	for i, sensor := range m.sensors {
+		if sensor.Name == ev.Name {
+			idx = i
+			break
+		}
+	}
+
+	switch ev.Kind {
+	case api.SensorDeleted:
+		if idx < 0 {
+			return -1
+		}
+		m.sensors[idx].Deleted = true
+		m.sensors[idx].Status = models.StatusDeleted
+		return -1
+	case api.SensorAdded:
+		if idx >= 0 {
+			// Already known (e.g. from a full resync racing the watcher) -
+			// fall through to the same in-place field update as a status
+			// change.
+			mergeSensorFields(m.sensors[idx], ev.Sensor)
+			return idx
+		}
+		m.sensors = append(m.sensors, ev.Sensor)
+		return len(m.sensors) - 1
+	case api.SensorStatusChanged:
+		if idx < 0 {
+			return -1
+		}
+		mergeSensorFields(m.sensors[idx], ev.Sensor)
+		return idx
+	}
+	return -1
+}
+
+// mergeSensorFields copies the API-origin fields of src into dst, leaving
+// dst's UI-only state (Metrics, MetricsUpdated, Selected, Loading) alone.
+func mergeSensorFields(dst, src *models.Sensor) {
+	dst.IP = src.IP
+	dst.Username = src.Username
+	dst.Status = src.Status
+	dst.Type = src.Type
+	dst.DevBranch = src.DevBranch
+	dst.StackName = src.StackName
+	dst.BrolinVersion = src.BrolinVersion
+	dst.CreatedAt = src.CreatedAt
+	dst.Deleted = src.Deleted
+	dst.Error = src.Error
+}
+
+// batchDeleteJob builds the batch.Job that deletes sensorName, mirroring
+// deleteSensor's single-sensor logic for use under a batch.Group.
+func (m Model) batchDeleteJob(sensorName string) batch.Job {
+	return func(ctx context.Context, progress func(message string)) error {
+		progress("deleting sensor")
+		if err := m.apiClient.DeleteSensor(ctx, sensorName); err != nil {
+			return err
+		}
+		removeSensorFromFile(m.config.SensorsFile, sensorName)
+		return nil
+	}
+}
+
+// batchUpgradeJob builds the batch.Job that upgrades ip to the latest
+// release, mirroring loadUpgradeInfo + runUpgrade's "upgrade to latest"
+// path. Unlike the single-sensor upgrade flow, it blocks on
+// RunUpgradeLatest instead of polling SSH availability phase by phase -
+// an intentional simplification so every sensor in the batch reports
+// through the same started/progress/done lifecycle.
+func (m Model) batchUpgradeJob(ip string) batch.Job {
+	return func(ctx context.Context, progress func(message string)) error {
+		progress("reading admin password")
+		adminPassword, err := m.sshClient.GetAdminPassword(ip)
+		if err != nil {
+			return fmt.Errorf("failed to get admin password: %w", err)
+		}
+
+		progress("upgrading to latest")
+		return m.sshClient.RunUpgradeLatest(ip, adminPassword)
+	}
+}
+
+// batchEnableFeaturesJob builds the batch.Job that runs
+// enable_sensor_features.sh against ip, mirroring runEnableFeatures's
+// single-sensor path.
+func (m Model) batchEnableFeaturesJob(ip string) batch.Job {
+	return func(ctx context.Context, progress func(message string)) error {
+		progress("enabling features")
+		_, err := m.sshClient.EnableFeatures(ip)
+		return err
+	}
+}
+
+// batchAddToFleetJob builds the batch.Job that runs
+// prepare_p1_automation.sh against ip, mirroring runAddToFleet's
+// single-sensor path.
+func (m Model) batchAddToFleetJob(ip string) batch.Job {
+	return func(ctx context.Context, progress func(message string)) error {
+		progress("adding to fleet")
+		_, err := m.sshClient.AddToFleetManager(ip)
+		return err
+	}
+}
+
 func (m Model) createSensor() tea.Cmd {
 	return func() tea.Msg {
-		sensorName, err := m.apiClient.CreateSensor()
+		sensorName, err := m.apiClient.CreateSensor(m.ctx)
 		if err != nil {
 			return deployStartedMsg{err: err}
 		}
@@ -2130,6 +4060,39 @@ func (m Model) createSensor() tea.Cmd {
 	}
 }
 
+// logEvent records message to the audit trail for operation (keyed by
+// sensorID) and returns the updated tail, so a handler can write
+// m.xLogs = m.logEvent(...) instead of appending to m.xLogs directly -
+// the slice becomes a read of audit.Logger's tail rather than its own
+// store.
+func (m Model) logEvent(operation, sensorID, sensorIP string, level audit.Level, message string) []string {
+	m.audit.Emit(m.ctx, audit.Event{
+		SensorID:  sensorID,
+		SensorIP:  sensorIP,
+		Operation: operation,
+		Level:     level,
+		Message:   message,
+	})
+	return m.audit.Tail(operation, sensorID)
+}
+
+// deployAdvanceEvent returns the deploy.Event that checkDeployStatus's
+// probe reports when it observes target for the first time, so the
+// deployStatusMsg handler can run it through deploy.Transition instead of
+// just comparing phase numbers.
+func deployAdvanceEvent(target deploy.Phase) (deploy.Event, bool) {
+	switch target {
+	case deploy.PhaseSSHPort:
+		return deploy.EventInstanceRunning, true
+	case deploy.PhaseSSHService:
+		return deploy.EventSSHPortOpen, true
+	case deploy.PhaseSeeding:
+		return deploy.EventSSHServiceReady, true
+	default:
+		return 0, false
+	}
+}
+
 func (m Model) checkDeployStatus() tea.Cmd {
 	return func() tea.Msg {
 		if m.deployingSensorName == "" {
@@ -2137,7 +4100,7 @@ func (m Model) checkDeployStatus() tea.Cmd {
 		}
 
 		// First check API status
-		sensor, err := m.apiClient.FetchSensor(m.deployingSensorName)
+		sensor, err := m.apiClient.FetchSensor(m.ctx, m.deployingSensorName)
 		if err != nil {
 			return deployStatusMsg{err: err}
 		}
@@ -2157,7 +4120,7 @@ func (m Model) checkDeployStatus() tea.Cmd {
 
 		// API says running with valid IP - now check SSH phases
 		// Phase 1: Check SSH port
-		if m.deployPhase < 1 {
+		if m.deployPhase < deploy.PhaseSSHPort {
 			if m.sshClient.CheckSSHPort(sensor.IP) {
 				return deployStatusMsg{
 					status:      "running",
@@ -2177,7 +4140,7 @@ func (m Model) checkDeployStatus() tea.Cmd {
 		}
 
 		// Phase 2: Check SSH service
-		if m.deployPhase < 2 {
+		if m.deployPhase < deploy.PhaseSSHService {
 			if m.sshClient.TestConnection(sensor.IP) {
 				return deployStatusMsg{
 					status:      "running",
@@ -2327,9 +4290,9 @@ type trafficConfigResultMsg struct {
 }
 
 // runConfigureTrafficGenerator configures the sensor as a traffic generator
-func (m Model) runConfigureTrafficGenerator(ip string) tea.Cmd {
+func (m Model) runConfigureTrafficGenerator(ip string, tools []string) tea.Cmd {
 	return func() tea.Msg {
-		err := m.sshClient.ConfigureTrafficGenerator(ip)
+		err := m.sshClient.ConfigureTrafficGenerator(ip, tools)
 		return trafficConfigResultMsg{err: err}
 	}
 }
@@ -2464,6 +4427,96 @@ func formatElapsed(d time.Duration) string {
 }
 
 func main() {
+	if len(os.Args) >= 3 && os.Args[1] == "config" && os.Args[2] == "doctor" {
+		cfg, err := config.Load()
+		if err != nil {
+			fmt.Printf("Failed to load config: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Print(cfg.Doctor())
+		return
+	}
+
+	if len(os.Args) >= 3 && os.Args[1] == "telemetry" && os.Args[2] == "run" {
+		once := len(os.Args) >= 4 && os.Args[3] == "--once"
+		if err := runTelemetry(once); err != nil {
+			fmt.Printf("telemetry: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if len(os.Args) >= 3 && os.Args[1] == "bulk" {
+		op := os.Args[2]
+		sensorList := ""
+		for i := 3; i < len(os.Args)-1; i++ {
+			if os.Args[i] == "--sensors" {
+				sensorList = os.Args[i+1]
+			}
+		}
+		if sensorList == "" {
+			fmt.Println("bulk: --sensors <name1,name2,...> is required")
+			os.Exit(1)
+		}
+		ok, err := runBulkCLI(op, strings.Split(sensorList, ","))
+		if err != nil {
+			fmt.Printf("bulk: %v\n", err)
+			os.Exit(1)
+		}
+		if !ok {
+			os.Exit(1)
+		}
+		return
+	}
+
+	if len(os.Args) >= 4 && os.Args[1] == "traffic" && os.Args[2] == "run" {
+		scenarioArg := os.Args[3]
+		sensorName := ""
+		for i := 4; i < len(os.Args)-1; i++ {
+			if os.Args[i] == "--sensor" {
+				sensorName = os.Args[i+1]
+			}
+		}
+		if sensorName == "" {
+			fmt.Println("traffic run: --sensor <name> is required")
+			os.Exit(1)
+		}
+		passed, err := runTrafficScenarioCLI(scenarioArg, sensorName)
+		if err != nil {
+			fmt.Printf("traffic: %v\n", err)
+			os.Exit(1)
+		}
+		if !passed {
+			os.Exit(1)
+		}
+		return
+	}
+
+	if len(os.Args) >= 2 && os.Args[1] == "serve" {
+		addr := ":8080"
+		pidfilePath := ""
+		for i := 2; i < len(os.Args)-1; i++ {
+			switch os.Args[i] {
+			case "--addr":
+				addr = os.Args[i+1]
+			case "--pidfile":
+				pidfilePath = os.Args[i+1]
+			}
+		}
+		if err := runServe(addr, pidfilePath); err != nil {
+			fmt.Printf("serve: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	pidfilePath := ""
+	for i := 1; i < len(os.Args)-1; i++ {
+		if os.Args[i] == "--pidfile" {
+			pidfilePath = os.Args[i+1]
+		}
+	}
+
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
@@ -2494,8 +4547,344 @@ func main() {
 		tea.WithMouseCellMotion(),
 	)
 
+	removePIDFile, err := writePIDFile(pidfilePath)
+	if err != nil {
+		fmt.Printf("Failed to write pidfile: %v\n", err)
+		os.Exit(1)
+	}
+	defer removePIDFile()
+
+	stopShutdown := watchForShutdown(m.sshClient, m.jobManager, m.configWatcher, cfg.ShutdownTimeout, p)
+	defer stopShutdown()
+
 	if _, err := p.Run(); err != nil {
 		fmt.Printf("Error running program: %v\n", err)
 		os.Exit(1)
 	}
 }
+
+// writePIDFile records the running process's PID at path, for external
+// orchestrators that manage ec2sensor as a daemon. A blank path is valid and
+// is a no-op - the returned func always removes whatever was written, so
+// defer removePIDFile() is safe even when nothing was.
+func writePIDFile(path string) (func(), error) {
+	if path == "" {
+		return func() {}, nil
+	}
+	if err := os.WriteFile(path, []byte(strconv.Itoa(os.Getpid())), 0644); err != nil {
+		return nil, fmt.Errorf("write %s: %w", path, err)
+	}
+	return func() { os.Remove(path) }, nil
+}
+
+// watchForShutdown traps SIGTERM/SIGINT/SIGHUP. SIGHUP triggers a config
+// reload through configWatcher (the same path a config.toml edit on disk
+// takes - see configReloadedMsg) and the handler keeps watching; SIGTERM/
+// SIGINT tear down every traffic generator sshClient started (see
+// Client.TrackedHosts), give jobManager's in-flight operations up to
+// timeout to finish their current phase (see drainJobs) so the .sensors
+// file and job registry land in a consistent state, then quit p. The
+// returned func stops watching and should be called once the program exits
+// normally.
+func watchForShutdown(sshClient *ssh.Client, jobManager *jobs.Manager, configWatcher *config.Watcher, timeout time.Duration, p *tea.Program) func() {
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGTERM, syscall.SIGINT, syscall.SIGHUP)
+
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case s := <-sig:
+				if s == syscall.SIGHUP {
+					if configWatcher != nil {
+						configWatcher.TriggerReload()
+					}
+					continue
+				}
+				ctx, cancel := context.WithTimeout(context.Background(), timeout)
+				if err := sshClient.ShutdownAll(ctx); err != nil {
+					fmt.Fprintf(os.Stderr, "shutdown: %v\n", err)
+				}
+				drainJobs(jobManager, time.Until(deadlineOr(ctx, timeout)))
+				cancel()
+				p.Quit()
+				return
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(sig)
+		close(done)
+	}
+}
+
+// deadlineOr returns ctx's deadline, or time.Now().Add(timeout) if ctx has
+// none - drainJobs gets the same grace period ShutdownAll was given rather
+// than a second independent budget.
+func deadlineOr(ctx context.Context, timeout time.Duration) time.Time {
+	if deadline, ok := ctx.Deadline(); ok {
+		return deadline
+	}
+	return time.Now().Add(timeout)
+}
+
+// drainJobs polls jobManager for any job still Running or Retrying, up to
+// timeout, so a SIGTERM/SIGINT doesn't cut off an SSH operation mid-phase -
+// each phase update already persists jobManager to disk (see Manager.save),
+// so letting the current phase land here is what makes the registry (and
+// opjournal's resumable-operation entries) consistent for the next launch.
+func drainJobs(jobManager *jobs.Manager, timeout time.Duration) {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		running := false
+		for _, snap := range jobManager.List() {
+			if snap.Status == jobs.StatusRunning || snap.Status == jobs.StatusRetrying {
+				running = true
+				break
+			}
+		}
+		if !running {
+			return
+		}
+		time.Sleep(250 * time.Millisecond)
+	}
+}
+
+// runTelemetry drives the telemetry collector headlessly: `telemetry run`
+// loops on cfg.TelemetryInterval until interrupted, `telemetry run --once`
+// collects and publishes a single report then returns.
+func runTelemetry(once bool) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	names, err := readSensorsFile(cfg.SensorsFile)
+	if err != nil {
+		return fmt.Errorf("read sensors file: %w", err)
+	}
+
+	apiClient := api.NewClient(cfg)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sensors := make(map[string]string, len(names))
+	for _, name := range names {
+		sensor, err := apiClient.FetchSensor(ctx, name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "telemetry: skipping %s: %v\n", name, err)
+			continue
+		}
+		if sensor.IsReady() {
+			sensors[name] = sensor.IP
+		}
+	}
+
+	fleet := ssh.NewFleet(ssh.NewClient(cfg), ssh.FleetOptions{})
+
+	sinks := []telemetry.Sink{telemetry.NewStdoutSink(os.Stdout)}
+	if cfg.TelemetryCollectorURL != "" {
+		sinks = append(sinks, telemetry.NewHTTPSink(
+			cfg.TelemetryCollectorURL, cfg.TelemetryBearerToken, cfg.TelemetryGzip, cfg.TelemetrySpoolDir,
+		))
+	}
+
+	collector := telemetry.NewCollector(fleet, sinks, cfg.TelemetryRedactFields)
+
+	if once {
+		report := collector.Collect(ctx, sensors)
+		for _, sink := range sinks {
+			if err := sink.Send(ctx, report); err != nil {
+				fmt.Fprintf(os.Stderr, "telemetry: sink failed: %v\n", err)
+			}
+		}
+		return nil
+	}
+
+	collector.Run(ctx, sensors, cfg.TelemetryInterval)
+	return nil
+}
+
+// runBulkCLI fans op out across names concurrently via internal/batch - the
+// same mechanism the TUI's multi-select confirm screens use - so a scripted
+// caller gets the same bounded-parallelism behavior as a human driving
+// ViewConfirmBatch. Unlike the TUI, sensors that fail to resolve are logged
+// to stderr and skipped rather than blocking the whole run.
+func runBulkCLI(op string, names []string) (bool, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return false, fmt.Errorf("load config: %w", err)
+	}
+
+	apiClient := api.NewClient(cfg)
+	sshClient := ssh.NewClient(cfg)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	jobs := make(map[string]batch.Job, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		sensor, err := apiClient.FetchSensor(ctx, name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "bulk: skipping %s: %v\n", name, err)
+			continue
+		}
+		ip := sensor.IP
+
+		switch op {
+		case "upgrade":
+			jobs[name] = func(ctx context.Context, progress func(string)) error {
+				progress("reading admin password")
+				adminPassword, err := sshClient.GetAdminPassword(ip)
+				if err != nil {
+					return fmt.Errorf("failed to get admin password: %w", err)
+				}
+				progress("upgrading to latest")
+				return sshClient.RunUpgradeLatest(ip, adminPassword)
+			}
+		case "enable-features":
+			jobs[name] = func(ctx context.Context, progress func(string)) error {
+				progress("enabling features")
+				_, err := sshClient.EnableFeatures(ip)
+				return err
+			}
+		case "add-to-fleet":
+			jobs[name] = func(ctx context.Context, progress func(string)) error {
+				progress("adding to fleet")
+				_, err := sshClient.AddToFleetManager(ip)
+				return err
+			}
+		default:
+			return false, fmt.Errorf("unknown bulk operation %q (want upgrade, enable-features, or add-to-fleet)", op)
+		}
+	}
+	if len(jobs) == 0 {
+		return false, fmt.Errorf("no sensors resolved")
+	}
+
+	retry := batch.DefaultRetryPolicy()
+	retry.MaxElapsedTime = cfg.BatchRetryTimeout
+	events := batch.NewGroup(cfg.MaxParallelOps).WithRetry(retry).Run(ctx, jobs)
+	succeeded, failed := 0, 0
+	for event := range events {
+		switch event.Phase {
+		case batch.PhaseDone:
+			succeeded++
+			fmt.Printf("[OK]   %s\n", event.Sensor)
+		case batch.PhaseErr:
+			failed++
+			fmt.Printf("[FAIL] %s: %v\n", event.Sensor, event.Err)
+		case batch.PhaseRetrying:
+			fmt.Printf("[RETRY] %s: %s\n", event.Sensor, event.Message)
+		}
+	}
+
+	fmt.Printf("bulk %s: %d succeeded, %d failed\n", op, succeeded, failed)
+	return failed == 0, nil
+}
+
+// runTrafficScenarioCLI loads scenarioArg - a path, or a bare name resolved
+// against traffic.ScenariosDir - and runs it headlessly against
+// sensorName, printing a per-stage pass/fail summary to stdout. The
+// returned bool is the report's Passed field, so main can exit non-zero on
+// a failed scenario the way a failing CI smoke test would.
+func runTrafficScenarioCLI(scenarioArg, sensorName string) (bool, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return false, fmt.Errorf("load config: %w", err)
+	}
+
+	scenarioPath := scenarioArg
+	if _, err := os.Stat(scenarioPath); err != nil {
+		scenarioPath = filepath.Join(traffic.ScenariosDir(), scenarioArg)
+	}
+	scenario, err := traffic.LoadScenario(scenarioPath)
+	if err != nil {
+		return false, err
+	}
+
+	apiClient := api.NewClient(cfg)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sensor, err := apiClient.FetchSensor(ctx, sensorName)
+	if err != nil {
+		return false, fmt.Errorf("fetch sensor %s: %w", sensorName, err)
+	}
+	if !sensor.IsReady() {
+		return false, fmt.Errorf("sensor %s is not ready (status %s)", sensorName, sensor.Status)
+	}
+
+	sshClient := ssh.NewClient(cfg)
+	report := traffic.NewRunner(sshClient).Run(ctx, sensor.IP, scenario)
+
+	for _, stage := range report.Stages {
+		if stage.Err != nil {
+			fmt.Printf("[FAIL] %s: error: %v\n", stage.Stage, stage.Err)
+			continue
+		}
+		status := "PASS"
+		if !stage.Passed {
+			status = "FAIL"
+		}
+		fmt.Printf("[%s] %s (%s)\n", status, stage.Stage, stage.Duration.Round(time.Second))
+		for _, failure := range stage.Failures {
+			fmt.Printf("       - %s\n", failure)
+		}
+	}
+	if report.Passed {
+		fmt.Printf("scenario %s: PASSED\n", report.Scenario)
+	} else {
+		fmt.Printf("scenario %s: FAILED\n", report.Scenario)
+	}
+
+	return report.Passed, nil
+}
+
+// runServe starts the headless HTTP API on addr: the same sensor
+// operations the TUI drives (deploy, upgrade, enable-features,
+// add-to-fleet, traffic start) become REST endpoints. It builds its own
+// jobs.Manager on the same registry file (jobs.DefaultPath) and its own
+// audit.Logger on the same configured sink that initialModel uses. Each
+// process reads the registry only at startup, so a job started over HTTP
+// shows up in the TUI's Background Jobs view the next time the TUI starts,
+// and vice versa.
+func runServe(addr, pidfilePath string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("config: %w", err)
+	}
+
+	removePIDFile, err := writePIDFile(pidfilePath)
+	if err != nil {
+		return err
+	}
+	defer removePIDFile()
+
+	apiClient := api.NewClient(cfg)
+	sshClient := ssh.NewClient(cfg)
+
+	jobManager := jobs.NewManager(jobs.DefaultPath())
+	if err := jobManager.Load(); err != nil {
+		return fmt.Errorf("load job registry: %w", err)
+	}
+
+	auditSink, err := auditSinkFor(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "serve: audit sink: %v, events won't be persisted\n", err)
+	}
+	auditLogger := audit.NewLogger(auditSink, 20)
+
+	srv := server.New(apiClient, sshClient, jobManager, auditLogger)
+	fmt.Printf("serving on %s\n", addr)
+	return http.ListenAndServe(addr, srv.Handler())
+}
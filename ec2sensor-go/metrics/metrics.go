@@ -0,0 +1,229 @@
+// Package metrics exposes Prometheus-format metrics for the sensor fleet and
+// the API client over an embedded HTTP endpoint, so fleet health can be
+// scraped from an existing Prometheus setup without a separate exporter.
+package metrics
+
+import (
+	"net/http"
+	"net/http/pprof"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/quanticsoul4772/ec2sensor-go/models"
+)
+
+// Metrics holds the collectors registered against a dedicated registry
+// (rather than prometheus.DefaultRegisterer) so embedding it doesn't leak
+// into a host process's own metrics.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	apiRequests *prometheus.CounterVec
+	apiRetries  *prometheus.CounterVec
+	apiLatency  *prometheus.HistogramVec
+
+	sensorsByStatus  *prometheus.GaugeVec
+	sensorCPU        *prometheus.GaugeVec
+	sensorMemory     *prometheus.GaugeVec
+	sensorDisk       *prometheus.GaugeVec
+	sensorPods       *prometheus.GaugeVec
+	sensorLastUpdate *prometheus.GaugeVec
+
+	sshErrors      *prometheus.CounterVec
+	trafficEvents  *prometheus.CounterVec
+	trafficBytes   *prometheus.CounterVec
+	trafficPackets *prometheus.CounterVec
+
+	pprof bool
+}
+
+// New creates a Metrics instance with all collectors registered.
+func New() *Metrics {
+	registry := prometheus.NewRegistry()
+
+	m := &Metrics{
+		registry: registry,
+		apiRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "ec2sensor_api_requests_total",
+			Help: "Total API requests made by the client, labeled by operation and result.",
+		}, []string{"operation", "result"}),
+		apiRetries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "ec2sensor_api_retries_total",
+			Help: "Total API request retries, labeled by operation.",
+		}, []string{"operation"}),
+		apiLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "ec2sensor_api_request_duration_seconds",
+			Help:    "API request latency in seconds, labeled by operation.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"operation"}),
+		sensorsByStatus: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "ec2sensor_sensors",
+			Help: "Number of sensors in the fleet, labeled by status.",
+		}, []string{"status"}),
+		sensorCPU: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "ec2sensor_sensor_cpu_percent",
+			Help: "Per-sensor CPU usage percentage.",
+		}, []string{"sensor", "dev_branch"}),
+		sensorMemory: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "ec2sensor_sensor_memory_percent",
+			Help: "Per-sensor memory usage percentage.",
+		}, []string{"sensor", "dev_branch"}),
+		sensorDisk: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "ec2sensor_sensor_disk_percent",
+			Help: "Per-sensor disk usage percentage.",
+		}, []string{"sensor", "dev_branch"}),
+		sensorPods: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "ec2sensor_sensor_pods",
+			Help: "Per-sensor running pod/service count.",
+		}, []string{"sensor", "dev_branch"}),
+		sensorLastUpdate: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "ec2sensor_sensor_last_updated_seconds",
+			Help: "Unix timestamp of the last metrics collection for a sensor.",
+		}, []string{"sensor", "dev_branch"}),
+		sshErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "ec2sensor_ssh_errors_total",
+			Help: "Total SSH command failures, labeled by sensor.",
+		}, []string{"sensor"}),
+		trafficEvents: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "ec2sensor_traffic_generator_events_total",
+			Help: "Traffic generator lifecycle events, labeled by sensor and event (start, stop).",
+		}, []string{"sensor", "event"}),
+		trafficBytes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "ec2sensor_traffic_generator_bytes_total",
+			Help: "Total bytes generated by the traffic generator, labeled by sensor.",
+		}, []string{"sensor"}),
+		trafficPackets: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "ec2sensor_traffic_generator_packets_total",
+			Help: "Total packets generated by the traffic generator, labeled by sensor.",
+		}, []string{"sensor"}),
+	}
+
+	registry.MustRegister(
+		m.apiRequests,
+		m.apiRetries,
+		m.apiLatency,
+		m.sensorsByStatus,
+		m.sensorCPU,
+		m.sensorMemory,
+		m.sensorDisk,
+		m.sensorPods,
+		m.sensorLastUpdate,
+		m.sshErrors,
+		m.trafficEvents,
+		m.trafficBytes,
+		m.trafficPackets,
+	)
+
+	return m
+}
+
+// EnablePprof mounts net/http/pprof's handlers under /debug/pprof/ on the
+// same listener as ListenAndServe, for live profiling of the collector
+// process.
+func (m *Metrics) EnablePprof() {
+	m.pprof = true
+}
+
+// ObserveSSHError records an SSH command failure against sensor.
+func (m *Metrics) ObserveSSHError(sensor string) {
+	m.sshErrors.WithLabelValues(sensor).Inc()
+}
+
+// ObserveTrafficEvent records a traffic generator lifecycle event ("start"
+// or "stop") against sensor.
+func (m *Metrics) ObserveTrafficEvent(sensor, event string) {
+	m.trafficEvents.WithLabelValues(sensor, event).Inc()
+}
+
+// ObserveTrafficGenerated adds to the running byte/packet totals generated
+// by sensor's traffic generator.
+func (m *Metrics) ObserveTrafficGenerated(sensor string, bytes, packets float64) {
+	m.trafficBytes.WithLabelValues(sensor).Add(bytes)
+	m.trafficPackets.WithLabelValues(sensor).Add(packets)
+}
+
+// ObserveSensorMetrics updates the per-sensor CPU/memory/disk/pods gauges
+// for a single sensor, identified by IP since that's all a push-mode caller
+// (ssh.PrometheusMetricsSink) has on hand. Unlike SetSensors, it doesn't
+// Reset the gauge vectors first, so it's safe to call incrementally as each
+// sensor's metrics come in rather than only on a full fleet refresh.
+func (m *Metrics) ObserveSensorMetrics(sensor string, metrics *models.SensorMetrics) {
+	labels := prometheus.Labels{"sensor": sensor, "dev_branch": ""}
+	m.sensorCPU.With(labels).Set(float64(metrics.CPU))
+	m.sensorMemory.With(labels).Set(float64(metrics.Memory))
+	m.sensorDisk.With(labels).Set(float64(metrics.Disk))
+	m.sensorPods.With(labels).Set(float64(metrics.Pods))
+	m.sensorLastUpdate.With(labels).Set(float64(time.Now().Unix()))
+}
+
+// ObserveRequest records the outcome of one API call: operation is "fetch",
+// "create" or "delete"; err is the call's final error (nil on success);
+// retries is the number of retries beyond the first attempt; duration is
+// the total time spent across all attempts.
+func (m *Metrics) ObserveRequest(operation string, err error, retries int, duration time.Duration) {
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	m.apiRequests.WithLabelValues(operation, result).Inc()
+	if retries > 0 {
+		m.apiRetries.WithLabelValues(operation).Add(float64(retries))
+	}
+	m.apiLatency.WithLabelValues(operation).Observe(duration.Seconds())
+}
+
+// SetSensors replaces the fleet and per-sensor gauges with the current
+// snapshot. Sensors not present in this call simply stop being reported
+// until they appear in a later snapshot.
+func (m *Metrics) SetSensors(sensors []*models.Sensor) {
+	m.sensorsByStatus.Reset()
+	m.sensorCPU.Reset()
+	m.sensorMemory.Reset()
+	m.sensorDisk.Reset()
+	m.sensorPods.Reset()
+	m.sensorLastUpdate.Reset()
+
+	counts := make(map[models.SensorStatus]int)
+	for _, sensor := range sensors {
+		counts[sensor.Status]++
+
+		if sensor.Metrics == nil {
+			continue
+		}
+		labels := prometheus.Labels{"sensor": sensor.Name, "dev_branch": sensor.DevBranch}
+		m.sensorCPU.With(labels).Set(float64(sensor.Metrics.CPU))
+		m.sensorMemory.With(labels).Set(float64(sensor.Metrics.Memory))
+		m.sensorDisk.With(labels).Set(float64(sensor.Metrics.Disk))
+		m.sensorPods.With(labels).Set(float64(sensor.Metrics.Pods))
+		if !sensor.MetricsUpdated.IsZero() {
+			m.sensorLastUpdate.With(labels).Set(float64(sensor.MetricsUpdated.Unix()))
+		}
+	}
+	for status, count := range counts {
+		m.sensorsByStatus.WithLabelValues(string(status)).Set(float64(count))
+	}
+}
+
+// Handler returns the HTTP handler serving this Metrics' registry in
+// Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// ListenAndServe starts an HTTP server on addr serving /metrics, and
+// /debug/pprof/* if EnablePprof was called. It blocks until the server
+// stops; callers typically run it in a goroutine.
+func (m *Metrics) ListenAndServe(addr string) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", m.Handler())
+	if m.pprof {
+		mux.HandleFunc("/debug/pprof/", pprof.Index)
+		mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+		mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+		mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+		mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	}
+	return http.ListenAndServe(addr, mux)
+}
@@ -47,6 +47,38 @@ type SensorMetrics struct {
 	Disk   int    // Disk usage percentage
 	Pods   int    // Number of running pods/services
 	Uptime string // Human-readable uptime
+
+	// NetRX and NetTX are cumulative network bytes received/transmitted,
+	// as reported by the collector. Zero on agents too old to report them.
+	NetRX int64
+	NetTX int64
+
+	// LoadAvg1/5/15 are the standard /proc/loadavg one/five/fifteen-minute
+	// averages. Zero on agents too old to report them.
+	LoadAvg1  float64
+	LoadAvg5  float64
+	LoadAvg15 float64
+
+	// TCPEstablished is the number of TCP connections in ESTABLISHED state.
+	TCPEstablished int
+}
+
+// SensorReport is the JSON wire format emitted by the remote metrics
+// collector, identified by its leading "{" byte. SchemaVersion lets
+// parseMetrics evolve the shape (new fields, renamed fields) without
+// breaking agents still on an older collector script.
+type SensorReport struct {
+	SchemaVersion  int     `json:"schema_version"`
+	CPU            int     `json:"cpu"`
+	Memory         int     `json:"memory"`
+	Disk           int     `json:"disk"`
+	Pods           int     `json:"pods"`
+	NetRX          int64   `json:"net_rx"`
+	NetTX          int64   `json:"net_tx"`
+	LoadAvg1       float64 `json:"load_avg_1"`
+	LoadAvg5       float64 `json:"load_avg_5"`
+	LoadAvg15      float64 `json:"load_avg_15"`
+	TCPEstablished int     `json:"tcp_established"`
 }
 
 // ShortID returns the last 8 characters of the sensor name
@@ -1,51 +1,389 @@
 package ssh
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
 	"net"
 	"os"
-	"os/exec"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+
 	"github.com/quanticsoul4772/ec2sensor-go/config"
+	"github.com/quanticsoul4772/ec2sensor-go/metrics"
 	"github.com/quanticsoul4772/ec2sensor-go/models"
 )
 
-// Client handles SSH connections for metrics collection
+// keepaliveInterval is how often pooled connections send a keepalive
+// request so dead connections (NATs dropping idle sessions, sensor reboots)
+// are noticed before the next command is run over them.
+const keepaliveInterval = 30 * time.Second
+
+// Client handles SSH connections for metrics collection. Connections are
+// pooled per host and reused across calls instead of paying a fresh
+// handshake for every command.
 type Client struct {
-	username string
-	password string
-	useKeys  bool
-	timeout  time.Duration
+	username        string
+	password        string
+	useKeys         bool
+	insecureHostKey bool
+	knownHostsFile  string
+	timeout         time.Duration
+
+	authMethods []ssh.AuthMethod
+
+	logger *slog.Logger
+
+	// metrics is nil unless SetMetrics is called, in which case SSH errors
+	// and traffic-generator events are reported to it.
+	metrics *metrics.Metrics
+
+	mu    sync.Mutex
+	conns map[string]*pooledConn
+
+	// dialErrors counts failed dial attempts, for PoolStats.
+	dialErrors int64
+
+	// trackedMu guards tracked, the set of hosts with a traffic generator
+	// started via StartTrafficGeneration/StartProfile/RunProfileCommand/
+	// ReplayPCAP that ShutdownAll should stop, mapped to which generator
+	// kind (genXxx in traffic.go) is running so it can be pkilled by name.
+	// Separate from mu since it guards unrelated state.
+	trackedMu sync.Mutex
+	tracked   map[string]string
+
+	// sinks receives every successful CollectMetrics result, in addition to
+	// the value returned to the caller. See WithSinks.
+	sinks []MetricsSink
+}
+
+// SetMetrics attaches m so runCommand failures and traffic-generator
+// lifecycle events are reported to it; it is a no-op otherwise.
+func (c *Client) SetMetrics(m *metrics.Metrics) {
+	c.metrics = m
+}
+
+// WithSinks registers additional MetricsSinks to fan out every successful
+// CollectMetrics result to, alongside the value CollectMetrics returns to
+// its caller. Sink errors are logged but never fail the collection itself.
+func (c *Client) WithSinks(sinks ...MetricsSink) *Client {
+	c.sinks = append(c.sinks, sinks...)
+	return c
+}
+
+// pooledConn is a cached connection to a single host, closed by its own
+// keepalive loop if the remote end stops responding.
+type pooledConn struct {
+	client *ssh.Client
+	done   chan struct{}
 }
 
 // NewClient creates a new SSH client
 func NewClient(cfg *config.Config) *Client {
-	return &Client{
-		username: cfg.SSHUsername,
-		password: cfg.SSHPassword,
-		useKeys:  cfg.SSHUseKeys,
-		timeout:  30 * time.Second, // Increased timeout for upgrade operations
+	c := &Client{
+		username:        cfg.SSHUsername,
+		password:        cfg.SSHPassword,
+		useKeys:         cfg.SSHUseKeys,
+		insecureHostKey: cfg.SSHInsecureHostKey,
+		knownHostsFile:  cfg.SSHKnownHostsFile,
+		timeout:         30 * time.Second, // Increased timeout for upgrade operations
+		conns:           make(map[string]*pooledConn),
+		logger:          discardLogger,
+	}
+	c.authMethods = c.buildAuthMethods()
+	return c
+}
+
+// buildAuthMethods assembles the auth methods to offer, in the order they
+// should be tried: agent (if SSH_AUTH_SOCK is set), private key files, then
+// password. The server picks whichever it accepts first.
+func (c *Client) buildAuthMethods() []ssh.AuthMethod {
+	var methods []ssh.AuthMethod
+
+	if sock := os.Getenv("SSH_AUTH_SOCK"); sock != "" {
+		if conn, err := net.Dial("unix", sock); err == nil {
+			methods = append(methods, ssh.PublicKeysCallback(agent.NewClient(conn).Signers))
+		}
+	}
+
+	if c.useKeys {
+		home := os.Getenv("HOME")
+		for _, name := range []string{"id_ed25519", "id_rsa"} {
+			keyPath := filepath.Join(home, ".ssh", name)
+			key, err := os.ReadFile(keyPath)
+			if err != nil {
+				continue
+			}
+			signer, err := ssh.ParsePrivateKey(key)
+			if err != nil {
+				continue
+			}
+			methods = append(methods, ssh.PublicKeys(signer))
+		}
+	}
+
+	if c.password != "" {
+		methods = append(methods, ssh.Password(c.password))
+	}
+
+	return methods
+}
+
+// hostKeyCallback returns InsecureIgnoreHostKey when c.insecureHostKey is
+// set, otherwise verifies against c.knownHostsFile.
+func (c *Client) hostKeyCallback() (ssh.HostKeyCallback, error) {
+	if c.insecureHostKey {
+		return ssh.InsecureIgnoreHostKey(), nil
+	}
+	return knownhosts.New(c.knownHostsFile)
+}
+
+// connKey identifies a pooled connection by the host and user it was
+// established as, since the same IP could be reused under a different user.
+func (c *Client) connKey(ip string) string {
+	return c.username + "@" + ip
+}
+
+// getConn returns a pooled connection to ip, dialing (and caching) a new one
+// if none exists yet.
+func (c *Client) getConn(ip string) (*ssh.Client, error) {
+	key := c.connKey(ip)
+
+	c.mu.Lock()
+	if pc, ok := c.conns[key]; ok {
+		c.mu.Unlock()
+		return pc.client, nil
+	}
+	c.mu.Unlock()
+
+	client, err := c.dial(ip)
+	if err != nil {
+		atomic.AddInt64(&c.dialErrors, 1)
+		return nil, err
+	}
+
+	pc := &pooledConn{client: client, done: make(chan struct{})}
+	c.mu.Lock()
+	c.conns[key] = pc
+	c.mu.Unlock()
+
+	go c.keepalive(key, pc)
+
+	return client, nil
+}
+
+// dial opens a fresh SSH connection to ip.
+func (c *Client) dial(ip string) (*ssh.Client, error) {
+	hostKeyCallback, err := c.hostKeyCallback()
+	if err != nil {
+		return nil, fmt.Errorf("load known_hosts: %w", err)
 	}
+
+	cfg := &ssh.ClientConfig{
+		User:            c.username,
+		Auth:            c.authMethods,
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         c.timeout,
+	}
+
+	return ssh.Dial("tcp", net.JoinHostPort(ip, "22"), cfg)
+}
+
+// keepalive periodically pings pc's connection; once a ping fails (the
+// remote end is gone) it evicts pc from the pool so the next call reconnects.
+func (c *Client) keepalive(key string, pc *pooledConn) {
+	ticker := time.NewTicker(keepaliveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-pc.done:
+			return
+		case <-ticker.C:
+			if _, _, err := pc.client.SendRequest("keepalive@openssh.com", true, nil); err != nil {
+				c.evict(key, pc)
+				return
+			}
+		}
+	}
+}
+
+// evict removes pc from the pool (if it's still the current entry for key)
+// and closes its underlying connection.
+func (c *Client) evict(key string, pc *pooledConn) {
+	c.mu.Lock()
+	if c.conns[key] == pc {
+		delete(c.conns, key)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-pc.done:
+	default:
+		close(pc.done)
+	}
+	pc.client.Close()
+}
+
+// PoolStats reports the current state of Client's connection pool, for
+// callers exposing SSH pool health alongside sensor metrics.
+type PoolStats struct {
+	// Active is the number of hosts with a live pooled connection.
+	Active int
+	// DialErrors is the cumulative count of failed dial attempts across the
+	// Client's lifetime.
+	DialErrors int64
+}
+
+// PoolStats returns a snapshot of the connection pool's current state. Each
+// pooled connection is dedicated to one host and reused for every command
+// against it (see getConn), so there's no separate idle/in-use split to
+// report - a pooled connection is either present (active) or evicted.
+func (c *Client) PoolStats() PoolStats {
+	c.mu.Lock()
+	active := len(c.conns)
+	c.mu.Unlock()
+
+	return PoolStats{
+		Active:     active,
+		DialErrors: atomic.LoadInt64(&c.dialErrors),
+	}
+}
+
+// isConnectionDead reports whether err indicates the underlying TCP
+// connection is gone, warranting a reconnect-and-retry rather than
+// surfacing the error directly.
+func isConnectionDead(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, net.ErrClosed) {
+		return true
+	}
+	return strings.Contains(err.Error(), "use of closed network connection") ||
+		strings.Contains(err.Error(), "EOF")
 }
 
-// CollectMetrics gathers resource metrics from a sensor via SSH
+// runCommand executes command on ip over a pooled connection, opening a new
+// session per call (sessions can't be reused concurrently) but reusing the
+// underlying TCP/SSH handshake. If the pooled connection turns out to be
+// dead, it reconnects once and retries.
+func (c *Client) runCommand(ip, command string) (string, error) {
+	start := time.Now()
+	output, err := c.runCommandOnce(ip, command)
+
+	if err != nil && isConnectionDead(err) {
+		// The pooled connection died between keepalives; evict it and retry
+		// once against a fresh connection.
+		key := c.connKey(ip)
+		c.mu.Lock()
+		pc := c.conns[key]
+		c.mu.Unlock()
+		if pc != nil {
+			c.evict(key, pc)
+		}
+		output, err = c.runCommandOnce(ip, command)
+	}
+
+	c.logger.Debug("ran command",
+		"sensor_ip", ip,
+		"command", redactCommand(command),
+		"duration", time.Since(start),
+		"error", err,
+	)
+	if err != nil && c.metrics != nil {
+		c.metrics.ObserveSSHError(ip)
+	}
+	return output, err
+}
+
+func (c *Client) runCommandOnce(ip, command string) (string, error) {
+	client, err := c.getConn(ip)
+	if err != nil {
+		return "", err
+	}
+
+	session, err := client.NewSession()
+	if err != nil {
+		return "", err
+	}
+	defer session.Close()
+
+	output, err := session.Output(command)
+	if err != nil {
+		// Output returns *ssh.ExitError for non-zero exits, with stderr
+		// unavailable here; callers already handle non-nil err by
+		// inspecting err.Error(), so just pass it through.
+		return string(output), err
+	}
+
+	return string(output), nil
+}
+
+// Close tears down every pooled connection. Safe to call even if some
+// connections were never established.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	conns := c.conns
+	c.conns = make(map[string]*pooledConn)
+	c.mu.Unlock()
+
+	var firstErr error
+	for key, pc := range conns {
+		select {
+		case <-pc.done:
+		default:
+			close(pc.done)
+		}
+		if err := pc.client.Close(); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("close %s: %w", key, err)
+		}
+	}
+	return firstErr
+}
+
+// CollectMetrics gathers resource metrics from a sensor via SSH in a single
+// round-trip, emitted as a versioned JSON SensorReport.
 func (c *Client) CollectMetrics(ip string) (*models.SensorMetrics, error) {
 	// Build the remote command for collecting metrics
 	remoteCmd := `cpu=$(awk "/^cpu / {printf \"%.0f\", (\$2+\$4)*100/(\$2+\$4+\$5)}" /proc/stat 2>/dev/null || echo "0"); \
         mem=$(free 2>/dev/null | awk "/Mem:/ {printf \"%.0f\", \$3/\$2*100}" || echo "0"); \
         disk=$(df / 2>/dev/null | awk "NR==2 {gsub(/%/,\"\"); print \$5}" || echo "0"); \
         pods=$(sudo corelightctl sensor status 2>/dev/null | grep -c "Ok" || sudo kubectl get pods --all-namespaces 2>/dev/null | grep -c Running || echo "0"); \
-        echo "${cpu}|${mem}|${disk}|${pods}"`
+        net=$(awk "NR>2 {rx+=\$2; tx+=\$10} END {printf \"%d %d\", rx, tx}" /proc/net/dev 2>/dev/null || echo "0 0"); \
+        load=$(awk "{print \$1, \$2, \$3}" /proc/loadavg 2>/dev/null || echo "0 0 0"); \
+        tcp=$(ss -tan state established 2>/dev/null | tail -n +2 | wc -l || echo "0"); \
+        netrx=$(echo $net | awk "{print \$1}"); nettx=$(echo $net | awk "{print \$2}"); \
+        load1=$(echo $load | awk "{print \$1}"); load5=$(echo $load | awk "{print \$2}"); load15=$(echo $load | awk "{print \$3}"); \
+        printf '{"schema_version":1,"cpu":%s,"memory":%s,"disk":%s,"pods":%s,"net_rx":%s,"net_tx":%s,"load_avg_1":%s,"load_avg_5":%s,"load_avg_15":%s,"tcp_established":%s}' "$cpu" "$mem" "$disk" "$pods" "$netrx" "$nettx" "$load1" "$load5" "$load15" "$tcp"`
 
 	output, err := c.runCommand(ip, remoteCmd)
 	if err != nil {
 		return nil, err
 	}
 
-	return parseMetrics(strings.TrimSpace(output))
+	result, err := parseMetrics(strings.TrimSpace(output))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sink := range c.sinks {
+		if err := sink.Send(ip, result); err != nil {
+			c.logger.Debug("metrics sink failed", "sensor_ip", ip, "error", err)
+		}
+	}
+
+	return result, nil
 }
 
 // GetUptime retrieves the system uptime from a sensor
@@ -131,21 +469,21 @@ func (c *Client) CheckSeeded(ip string) (bool, string, error) {
 	}
 
 	seededValue := strings.TrimSpace(output)
-	
+
 	// Handle empty or unexpected output
 	if seededValue == "" {
 		return false, "empty response", fmt.Errorf("broala-config returned empty response - command may not exist yet")
 	}
-	
+
 	// Check for error messages in the output (broala-config might return error text)
 	if strings.Contains(seededValue, "error") || strings.Contains(seededValue, "Error") || strings.Contains(seededValue, "not found") {
 		return false, "config error", fmt.Errorf("broala-config error: %s", seededValue)
 	}
-	
+
 	if seededValue == "1" {
 		return true, seededValue, nil
 	}
-	
+
 	// Return the actual value (could be "0" or something else)
 	return false, seededValue, nil
 }
@@ -250,17 +588,23 @@ func (c *Client) GetAvailableUpdates(ip, adminPassword string) ([]string, error)
 
 // RunUpgradeLatest runs the upgrade to latest using corelight-client updates apply
 func (c *Client) RunUpgradeLatest(ip, adminPassword string) error {
+	log := c.opLogger(ip, "RunUpgradeLatest")
+	log.Info("starting upgrade", "phase", "start")
+
 	cmd := fmt.Sprintf("corelight-client -b 192.0.2.1:30443 --ssl-no-verify-certificate -u admin -p %s updates apply 2>&1", adminPassword)
 	output, err := c.runCommand(ip, cmd)
 	if err != nil {
+		log.Info("upgrade finished", "phase", "done", "outcome", "failed", "error", err)
 		return fmt.Errorf("upgrade command failed: %v", err)
 	}
 
 	// Check for success in output
 	if !strings.Contains(output, "success") && !strings.Contains(output, "True") {
+		log.Info("upgrade finished", "phase", "done", "outcome", "failed")
 		return fmt.Errorf("upgrade may have failed: %s", output)
 	}
 
+	log.Info("upgrade finished", "phase", "done", "outcome", "success")
 	return nil
 }
 
@@ -312,7 +656,7 @@ func (c *Client) GetUpgradeLogLines(ip string, numLines int) (map[string][]strin
 	}
 
 	for _, lf := range logFiles {
-		cmd := fmt.Sprintf("sudo tail -n %d %s 2>/dev/null || tail -n %d %s 2>/dev/null || echo ''", 
+		cmd := fmt.Sprintf("sudo tail -n %d %s 2>/dev/null || tail -n %d %s 2>/dev/null || echo ''",
 			numLines, lf.path, numLines, lf.path)
 		output, err := c.runCommand(ip, cmd)
 		if err == nil && strings.TrimSpace(output) != "" {
@@ -328,13 +672,13 @@ func (c *Client) GetUpgradeLogLines(ip string, numLines int) (map[string][]strin
 
 // GetUpgradeStatus returns a detailed status of the upgrade process
 type UpgradeStatus struct {
-	SSHAvailable    bool
-	ProcessRunning  bool
-	CurrentPhase    string
-	RecentLogs      []string
-	CurrentVersion  string
-	DpkgLocked      bool
-	RebootDetected  bool
+	SSHAvailable   bool
+	ProcessRunning bool
+	CurrentPhase   string
+	RecentLogs     []string
+	CurrentVersion string
+	DpkgLocked     bool
+	RebootDetected bool
 }
 
 // CheckUpgradeStatus performs a comprehensive check of upgrade progress
@@ -374,7 +718,7 @@ func (c *Client) CheckUpgradeStatus(ip, adminPassword string) (*UpgradeStatus, e
 
 	// Get recent log entries (combined from dpkg and apt)
 	var recentLogs []string
-	
+
 	// Get last 5 dpkg log entries
 	dpkgOutput, _ := c.runCommand(ip, "sudo tail -n 5 /var/log/dpkg.log 2>/dev/null | grep -E 'status|install|configure' | tail -3")
 	if strings.TrimSpace(dpkgOutput) != "" {
@@ -428,9 +772,12 @@ type EnableFeaturesResult struct {
 // EnableFeatures runs the enable_sensor_features commands on the sensor
 // Returns detailed result with actual status from the commands
 func (c *Client) EnableFeatures(ip string) (*EnableFeaturesResult, error) {
+	log := c.opLogger(ip, "EnableFeatures")
+	log.Info("enabling features", "phase", "start")
 	result := &EnableFeaturesResult{}
 
 	// First check if sensor is seeded (required for features to work)
+	log.Debug("checking seeded status", "phase", "check-seeded")
 	seeded, seededValue, seedErr := c.CheckSeeded(ip)
 	if !seeded {
 		result.Success = false
@@ -487,10 +834,12 @@ func (c *Client) EnableFeatures(ip string) (*EnableFeaturesResult, error) {
 			}
 			result.Output = strings.Join(debugLines, "\n")
 		}
+		log.Info("enable features finished", "phase", "done", "outcome", "not-seeded")
 		return result, nil
 	}
 
 	// Run the config set commands
+	log.Debug("applying feature configuration", "phase", "apply-config")
 	commands := `set +u
 echo "=== Setting feature configuration ==="
 FAILED=0
@@ -558,6 +907,7 @@ fi`
 		result.Success = false
 		result.Error = err
 		result.Message = "SSH command failed"
+		log.Info("enable features finished", "phase", "done", "outcome", "ssh-failed", "error", err)
 		return result, nil
 	}
 
@@ -580,11 +930,15 @@ fi`
 		result.Message = "Failed to set feature configuration"
 	}
 
+	log.Info("enable features finished", "phase", "done", "outcome", result.Message)
 	return result, nil
 }
 
 // AddToFleetManager runs the fleet manager registration script
 func (c *Client) AddToFleetManager(ip string) (string, error) {
+	log := c.opLogger(ip, "AddToFleetManager")
+	log.Info("registering with fleet manager", "phase", "start")
+
 	// Run the prepare_p1_automation equivalent commands
 	// This is simplified - the full script does more setup
 	commands := `echo "Adding sensor to fleet manager..."
@@ -606,62 +960,138 @@ sudo corelight-client -b 192.0.2.1:30443 --ssl-no-verify-certificate -u admin -p
 echo "Fleet manager configuration complete"
 echo "Sensor should now appear in fleet manager at https://$FLEET_IP"`
 
-	return c.runCommand(ip, commands)
+	output, err := c.runCommand(ip, commands)
+	if err != nil {
+		log.Info("fleet manager registration finished", "phase", "done", "outcome", "failed", "error", err)
+		return output, err
+	}
+	log.Info("fleet manager registration finished", "phase", "done", "outcome", "success")
+	return output, nil
 }
 
-// ConfigureTrafficGenerator sets up the sensor as a traffic generator
-func (c *Client) ConfigureTrafficGenerator(ip string) error {
-	commands := `echo "Configuring traffic generator..."
+// ConfigureTrafficGenerator sets up the sensor as a traffic generator,
+// installing simple_traffic_generator.py plus whatever extra apt packages
+// tools names - the union of the traffic.ProfileSpec.Tools the caller
+// intends to run (hping3, curl-loader, tcpreplay, ...). tools may be empty
+// for a sensor that will only ever run the built-in JSON-descriptor modes.
+func (c *Client) ConfigureTrafficGenerator(ip string, tools []string) error {
+	installCmd := ""
+	if len(tools) > 0 {
+		installCmd = fmt.Sprintf("sudo apt-get install -y %s\n", strings.Join(tools, " "))
+	}
+
+	commands := installCmd + `echo "Configuring traffic generator..."
 # Create simple traffic generator script
 cat > /tmp/simple_traffic_generator.py << 'SCRIPT'
 #!/usr/bin/env python3
 import socket
+import subprocess
 import time
+import json
 import argparse
 import random
 import string
+import urllib.request
 
 def generate_payload(size=100):
     return ''.join(random.choices(string.ascii_letters + string.digits, k=size)).encode()
 
-def main():
-    parser = argparse.ArgumentParser(description='Simple traffic generator')
-    parser.add_argument('-t', '--target', required=True, help='Target IP')
-    parser.add_argument('-p', '--port', type=int, default=5555, help='Target port')
-    parser.add_argument('--protocol', default='udp', choices=['udp', 'tcp'], help='Protocol')
-    parser.add_argument('-r', '--rate', type=int, default=1000, help='Packets per second')
-    parser.add_argument('-D', '--duration', type=int, default=60, help='Duration in seconds')
-    args = parser.parse_args()
-    
-    print(f"Starting {args.protocol.upper()} traffic to {args.target}:{args.port}")
-    print(f"Rate: {args.rate} pps, Duration: {args.duration}s")
-    
-    if args.protocol == 'udp':
+def send_packets(target, port, protocol, pps, duration):
+    if protocol == 'udp':
         sock = socket.socket(socket.AF_INET, socket.SOCK_DGRAM)
     else:
         sock = socket.socket(socket.AF_INET, socket.SOCK_STREAM)
-        sock.connect((args.target, args.port))
-    
+        sock.connect((target, port))
+
     start = time.time()
     count = 0
-    interval = 1.0 / args.rate
-    
-    while time.time() - start < args.duration:
+    interval = 1.0 / pps if pps > 0 else 0
+
+    while time.time() - start < duration:
         payload = generate_payload()
         try:
-            if args.protocol == 'udp':
-                sock.sendto(payload, (args.target, args.port))
+            if protocol == 'udp':
+                sock.sendto(payload, (target, port))
             else:
                 sock.send(payload)
             count += 1
         except Exception as e:
             print(f"Error: {e}")
             break
-        time.sleep(interval)
-    
+        if interval:
+            time.sleep(interval)
+
     print(f"Sent {count} packets in {time.time() - start:.1f}s")
     sock.close()
 
+def run_constant(p):
+    print(f"Starting {p['protocol'].upper()} traffic to {p['target']}:{p['port']}")
+    print(f"Rate: {p['pps']} pps, Duration: {p['duration']}s")
+    send_packets(p['target'], p['port'], p['protocol'], p['pps'], p['duration'])
+
+def run_burst(p):
+    print(f"Starting burst traffic to {p['target']}:{p['port']}: {p['count']} packets every {p['interval']}s")
+    start = time.time()
+    bursts = 0
+    while time.time() - start < p['duration']:
+        send_packets(p['target'], p['port'], p['protocol'], p['count'] / max(p['interval'], 0.001), p['interval'])
+        bursts += 1
+        time.sleep(p['interval'])
+    print(f"Sent {bursts} bursts in {time.time() - start:.1f}s")
+
+def run_ramp(p):
+    print(f"Starting ramp traffic to {p['target']}:{p['port']}: {p['start_pps']} -> {p['end_pps']} pps over {p['duration']}s")
+    steps = max(int(p['duration']), 1)
+    for step in range(steps):
+        pps = p['start_pps'] + (p['end_pps'] - p['start_pps']) * step / steps
+        send_packets(p['target'], p['port'], p['protocol'], max(pps, 1), 1)
+
+def run_http(p):
+    print(f"Starting HTTP load against {p['url']} at {p['rps']} rps for {p['duration']}s")
+    start = time.time()
+    count = 0
+    interval = 1.0 / p['rps'] if p['rps'] > 0 else 0
+    while time.time() - start < p['duration']:
+        try:
+            urllib.request.urlopen(p['url'], timeout=5).read()
+            count += 1
+        except Exception as e:
+            print(f"Error: {e}")
+        if interval:
+            time.sleep(interval)
+    print(f"Sent {count} requests in {time.time() - start:.1f}s")
+
+def run_icmp(p):
+    print(f"Starting ICMP flood against {p['target']} at {p['pps']} pps for {p['duration']}s")
+    interval = 1.0 / p['pps'] if p['pps'] > 0 else 1
+    proc = subprocess.Popen(
+        ['ping', '-i', str(interval), '-w', str(p['duration']), p['target']],
+        stdout=subprocess.DEVNULL, stderr=subprocess.DEVNULL,
+    )
+    proc.wait()
+    print("ICMP flood finished")
+
+RUNNERS = {
+    'constant': run_constant,
+    'burst': run_burst,
+    'ramp': run_ramp,
+    'http': run_http,
+    'icmp': run_icmp,
+}
+
+def main():
+    parser = argparse.ArgumentParser(description='Traffic generator')
+    parser.add_argument('--profile', required=True, help='Path to a JSON traffic profile descriptor')
+    args = parser.parse_args()
+
+    with open(args.profile) as f:
+        profile = json.load(f)
+
+    runner = RUNNERS.get(profile.get('mode'))
+    if runner is None:
+        raise SystemExit(f"unknown traffic profile mode: {profile.get('mode')!r}")
+    runner(profile)
+
 if __name__ == '__main__':
     main()
 SCRIPT
@@ -672,20 +1102,50 @@ echo "Traffic generator installed at /tmp/simple_traffic_generator.py"`
 	return err
 }
 
-// StartTrafficGeneration starts the traffic generator in background
+// StartTrafficGeneration starts a constant-rate traffic generator in the
+// background. It's a thin wrapper around StartProfile for callers that only
+// need the original UDP/TCP flood behavior; new callers wanting burst, ramp,
+// HTTP or ICMP patterns should build the matching TrafficProfile directly
+// and call StartProfile.
 func (c *Client) StartTrafficGeneration(ip, targetIP, targetPort, protocol, pps, duration string) error {
 	if duration == "0" {
 		duration = "999999" // Continuous
 	}
-	cmd := fmt.Sprintf("cd /tmp && nohup python3 simple_traffic_generator.py -t %s -p %s --protocol %s -r %s -D %s > /tmp/traffic.log 2>&1 &",
-		targetIP, targetPort, protocol, pps, duration)
-	_, err := c.runCommand(ip, cmd)
-	return err
+
+	port, err := strconv.Atoi(targetPort)
+	if err != nil {
+		return fmt.Errorf("invalid port %q: %w", targetPort, err)
+	}
+	rate, err := strconv.Atoi(pps)
+	if err != nil {
+		return fmt.Errorf("invalid rate %q: %w", pps, err)
+	}
+	seconds, err := strconv.Atoi(duration)
+	if err != nil {
+		return fmt.Errorf("invalid duration %q: %w", duration, err)
+	}
+
+	return c.StartProfile(ip, ConstantRate{
+		Target:   targetIP,
+		Port:     port,
+		Protocol: protocol,
+		PPS:      rate,
+		Duration: seconds,
+	})
 }
 
-// StopTrafficGeneration stops any running traffic generation
+// StopTrafficGeneration stops whichever traffic generator is running on ip -
+// simple_traffic_generator.py, the profile script RunProfileCommand pushed,
+// or tcpreplay, per the kind trackHost recorded when it was started.
 func (c *Client) StopTrafficGeneration(ip string) error {
-	_, err := c.runCommand(ip, "sudo pkill -f simple_traffic_generator.py 2>/dev/null || true")
+	cmd := fmt.Sprintf("sudo pkill -f %s 2>/dev/null || true", c.trackedKind(ip))
+	_, err := c.runCommand(ip, cmd)
+	if err == nil {
+		c.untrackHost(ip)
+		if c.metrics != nil {
+			c.metrics.ObserveTrafficEvent(ip, "stop")
+		}
+	}
 	return err
 }
 
@@ -698,51 +1158,63 @@ func (c *Client) GetTrafficStatus(ip string) (string, error) {
 	return strings.TrimSpace(output), nil
 }
 
-// runCommand executes a command on a remote host via SSH
-func (c *Client) runCommand(ip, command string) (string, error) {
-	var cmd *exec.Cmd
-
-	sshArgs := []string{
-		"-o", "StrictHostKeyChecking=no",
-		"-o", "UserKnownHostsFile=/dev/null",
-		"-o", fmt.Sprintf("ConnectTimeout=%d", int(c.timeout.Seconds())),
-		"-o", "BatchMode=yes",
-		fmt.Sprintf("%s@%s", c.username, ip),
-		command,
+// parseMetrics parses a metrics report from a sensor. Reports starting with
+// "{" are the current JSON SensorReport format (see models.SensorReport);
+// anything else falls back to the legacy pipe-separated
+// "cpu|memory|disk|pods" format, so agents that haven't picked up the new
+// collector script yet keep reporting during a rollout.
+func parseMetrics(output string) (*models.SensorMetrics, error) {
+	if output == "" {
+		return nil, fmt.Errorf("empty metrics output")
 	}
-
-	if c.useKeys {
-		cmd = exec.Command("ssh", sshArgs...)
-	} else if c.password != "" {
-		// Use sshpass for password authentication
-		sshpassArgs := append([]string{"-e", "ssh"}, sshArgs...)
-		cmd = exec.Command("sshpass", sshpassArgs...)
-		// Inherit parent environment and add SSHPASS
-		cmd.Env = append(os.Environ(), fmt.Sprintf("SSHPASS=%s", c.password))
-	} else {
-		// Try without password (will prompt or fail)
-		cmd = exec.Command("ssh", sshArgs...)
+	if output[0] == '{' {
+		return parseMetricsJSON(output)
 	}
+	return parseMetricsPipe(output)
+}
 
-	output, err := cmd.Output()
-	if err != nil {
-		return "", err
+func parseMetricsJSON(output string) (*models.SensorMetrics, error) {
+	var report models.SensorReport
+	if err := json.Unmarshal([]byte(output), &report); err != nil {
+		return nil, fmt.Errorf("parse metrics report: %w", err)
 	}
 
-	return string(output), nil
+	return &models.SensorMetrics{
+		CPU:            report.CPU,
+		Memory:         report.Memory,
+		Disk:           report.Disk,
+		Pods:           report.Pods,
+		NetRX:          report.NetRX,
+		NetTX:          report.NetTX,
+		LoadAvg1:       report.LoadAvg1,
+		LoadAvg5:       report.LoadAvg5,
+		LoadAvg15:      report.LoadAvg15,
+		TCPEstablished: report.TCPEstablished,
+	}, nil
 }
 
-// parseMetrics parses the pipe-separated metrics string
-func parseMetrics(output string) (*models.SensorMetrics, error) {
+func parseMetricsPipe(output string) (*models.SensorMetrics, error) {
 	parts := strings.Split(output, "|")
 	if len(parts) != 4 {
 		return nil, fmt.Errorf("invalid metrics format: %s", output)
 	}
 
-	cpu, _ := strconv.Atoi(parts[0])
-	mem, _ := strconv.Atoi(parts[1])
-	disk, _ := strconv.Atoi(parts[2])
-	pods, _ := strconv.Atoi(parts[3])
+	cpu, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid cpu value %q: %w", parts[0], err)
+	}
+	mem, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid memory value %q: %w", parts[1], err)
+	}
+	disk, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid disk value %q: %w", parts[2], err)
+	}
+	pods, err := strconv.Atoi(parts[3])
+	if err != nil {
+		return nil, fmt.Errorf("invalid pods value %q: %w", parts[3], err)
+	}
 
 	return &models.SensorMetrics{
 		CPU:    cpu,
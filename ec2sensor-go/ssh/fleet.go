@@ -0,0 +1,290 @@
+package ssh
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/quanticsoul4772/ec2sensor-go/models"
+)
+
+// Fleet runs Client operations across many hosts concurrently through a
+// bounded worker pool, instead of callers looping over IPs and serializing
+// every SSH round trip.
+type Fleet struct {
+	client *Client
+	opts   FleetOptions
+}
+
+// FleetOptions configures a Fleet's concurrency, per-host deadline and
+// retry behavior.
+type FleetOptions struct {
+	// Concurrency bounds how many hosts are operated on at once; <= 0 falls
+	// back to defaultFleetOptions.Concurrency.
+	Concurrency int
+	// PerHostTimeout bounds how long a single host's operation may run
+	// before it's abandoned as hung; <= 0 falls back to
+	// defaultFleetOptions.PerHostTimeout.
+	PerHostTimeout time.Duration
+	// MaxRetries is how many additional attempts a transient SSH error
+	// (refused/timeout) gets before the host is reported as failed; <= 0
+	// falls back to defaultFleetOptions.MaxRetries.
+	MaxRetries int
+	// RetryBackoff is the fixed delay between retry attempts; <= 0 falls
+	// back to defaultFleetOptions.RetryBackoff.
+	RetryBackoff time.Duration
+}
+
+// defaultFleetOptions fills in any zero-valued field passed to NewFleet.
+var defaultFleetOptions = FleetOptions{
+	Concurrency:    8,
+	PerHostTimeout: 30 * time.Second,
+	MaxRetries:     2,
+	RetryBackoff:   2 * time.Second,
+}
+
+// NewFleet wraps client for bulk operations. Zero-valued fields in opts
+// fall back to defaultFleetOptions.
+func NewFleet(client *Client, opts FleetOptions) *Fleet {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = defaultFleetOptions.Concurrency
+	}
+	if opts.PerHostTimeout <= 0 {
+		opts.PerHostTimeout = defaultFleetOptions.PerHostTimeout
+	}
+	if opts.MaxRetries <= 0 {
+		opts.MaxRetries = defaultFleetOptions.MaxRetries
+	}
+	if opts.RetryBackoff <= 0 {
+		opts.RetryBackoff = defaultFleetOptions.RetryBackoff
+	}
+	return &Fleet{client: client, opts: opts}
+}
+
+// Client returns the Client a Fleet wraps, for callers that need to combine
+// several of its methods per host under the same worker pool (see
+// RunStream).
+func (f *Fleet) Client() *Client {
+	return f.client
+}
+
+// FleetResult is the outcome of a Fleet operation on a single host.
+type FleetResult[T any] struct {
+	IP    string
+	Value T
+	Err   error
+}
+
+// isTransientSSHError reports whether err looks like a retryable connection
+// hiccup (refused, timed out, no route) rather than a terminal failure.
+func isTransientSSHError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "connection refused") ||
+		strings.Contains(msg, "timed out") ||
+		strings.Contains(msg, "timeout") ||
+		strings.Contains(msg, "no route to host")
+}
+
+// runStream runs fn for every ip in ips through a worker pool bounded by
+// f.opts.Concurrency, retrying transient errors up to f.opts.MaxRetries
+// times, and streams each result on the returned channel as soon as it
+// completes. The channel is closed once every host has been processed.
+func runStream[T any](ctx context.Context, f *Fleet, ips []string, fn func(ip string) (T, error)) <-chan FleetResult[T] {
+	out := make(chan FleetResult[T], len(ips))
+	if len(ips) == 0 {
+		close(out)
+		return out
+	}
+
+	sem := make(chan struct{}, f.opts.Concurrency)
+	var wg sync.WaitGroup
+
+	for _, ip := range ips {
+		wg.Add(1)
+		go func(ip string) {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				out <- FleetResult[T]{IP: ip, Err: ctx.Err()}
+				return
+			}
+			defer func() { <-sem }()
+
+			out <- runHost(ctx, f, ip, fn)
+		}(ip)
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out
+}
+
+// runHost runs fn against ip, retrying transient SSH errors up to
+// f.opts.MaxRetries times, and bounds each attempt by f.opts.PerHostTimeout
+// so one hung sensor can't stall the whole batch.
+func runHost[T any](ctx context.Context, f *Fleet, ip string, fn func(ip string) (T, error)) FleetResult[T] {
+	var lastErr error
+
+	for attempt := 0; attempt <= f.opts.MaxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return FleetResult[T]{IP: ip, Err: ctx.Err()}
+			case <-time.After(f.opts.RetryBackoff):
+			}
+		}
+
+		value, err := callWithDeadline(ctx, f.opts.PerHostTimeout, ip, fn)
+		if err == nil {
+			return FleetResult[T]{IP: ip, Value: value}
+		}
+		lastErr = err
+		if !isTransientSSHError(err) {
+			break
+		}
+	}
+
+	var zero T
+	return FleetResult[T]{IP: ip, Value: zero, Err: lastErr}
+}
+
+// callWithDeadline runs fn(ip) in its own goroutine and returns early with a
+// deadline-exceeded error if it doesn't finish within timeout or ctx is
+// cancelled first. Client's methods don't accept a context themselves, so a
+// hung call's goroutine is abandoned rather than killed - it can't corrupt
+// later calls since each uses its own pooled connection and ssh.Session.
+func callWithDeadline[T any](ctx context.Context, timeout time.Duration, ip string, fn func(ip string) (T, error)) (T, error) {
+	type result struct {
+		value T
+		err   error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		value, err := fn(ip)
+		ch <- result{value, err}
+	}()
+
+	var timeoutCh <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		timeoutCh = timer.C
+	}
+
+	select {
+	case r := <-ch:
+		return r.value, r.err
+	case <-timeoutCh:
+		var zero T
+		return zero, fmt.Errorf("operation on %s timed out after %s", ip, timeout)
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// aggregateErrors summarizes per-host failures into a single error, or nil
+// if errs is empty.
+func aggregateErrors(total int, errs []string) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d of %d hosts failed: %s", len(errs), total, strings.Join(errs, "; "))
+}
+
+// RunStream runs fn across ips using f's worker pool, retry and per-host
+// timeout behavior, for operations not covered by a dedicated ...Stream
+// method below (e.g. telemetry's multi-call per-sensor gather).
+func RunStream[T any](ctx context.Context, f *Fleet, ips []string, fn func(ip string) (T, error)) <-chan FleetResult[T] {
+	return runStream(ctx, f, ips, fn)
+}
+
+// CollectMetricsStream runs CollectMetrics across ips concurrently, yielding
+// each result as soon as it completes so a UI can update incrementally.
+func (f *Fleet) CollectMetricsStream(ctx context.Context, ips []string) <-chan FleetResult[*models.SensorMetrics] {
+	return runStream(ctx, f, ips, f.client.CollectMetrics)
+}
+
+// CollectMetricsAll runs CollectMetrics across ips concurrently and collects
+// the results into a map keyed by IP, plus an aggregated error for any
+// hosts that failed.
+func (f *Fleet) CollectMetricsAll(ctx context.Context, ips []string) (map[string]*models.SensorMetrics, error) {
+	results := make(map[string]*models.SensorMetrics, len(ips))
+	var errs []string
+	for r := range f.CollectMetricsStream(ctx, ips) {
+		results[r.IP] = r.Value
+		if r.Err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", r.IP, r.Err))
+		}
+	}
+	return results, aggregateErrors(len(ips), errs)
+}
+
+// SeededStatus bundles CheckSeeded's (bool, string) return into a single
+// value so it fits FleetResult.
+type SeededStatus struct {
+	Seeded bool
+	Value  string
+}
+
+// CheckSeededStream runs CheckSeeded across ips concurrently, yielding each
+// result as soon as it completes.
+func (f *Fleet) CheckSeededStream(ctx context.Context, ips []string) <-chan FleetResult[SeededStatus] {
+	return runStream(ctx, f, ips, func(ip string) (SeededStatus, error) {
+		seeded, value, err := f.client.CheckSeeded(ip)
+		return SeededStatus{Seeded: seeded, Value: value}, err
+	})
+}
+
+// CheckSeededAll runs CheckSeeded across ips concurrently and collects the
+// results into a map keyed by IP, plus an aggregated error for any hosts
+// that failed.
+func (f *Fleet) CheckSeededAll(ctx context.Context, ips []string) (map[string]SeededStatus, error) {
+	results := make(map[string]SeededStatus, len(ips))
+	var errs []string
+	for r := range f.CheckSeededStream(ctx, ips) {
+		results[r.IP] = r.Value
+		if r.Err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", r.IP, r.Err))
+		}
+	}
+	return results, aggregateErrors(len(ips), errs)
+}
+
+// CheckUpgradeStatusStream runs CheckUpgradeStatus across the hosts in
+// adminPasswords (keyed by IP) concurrently, yielding each result as soon as
+// it completes so a UI can track an upgrade sweep incrementally.
+func (f *Fleet) CheckUpgradeStatusStream(ctx context.Context, adminPasswords map[string]string) <-chan FleetResult[*UpgradeStatus] {
+	ips := make([]string, 0, len(adminPasswords))
+	for ip := range adminPasswords {
+		ips = append(ips, ip)
+	}
+	return runStream(ctx, f, ips, func(ip string) (*UpgradeStatus, error) {
+		return f.client.CheckUpgradeStatus(ip, adminPasswords[ip])
+	})
+}
+
+// CheckUpgradeStatusAll runs CheckUpgradeStatus across the hosts in
+// adminPasswords (keyed by IP) concurrently and collects the results into a
+// map keyed by IP, plus an aggregated error for any hosts that failed.
+func (f *Fleet) CheckUpgradeStatusAll(ctx context.Context, adminPasswords map[string]string) (map[string]*UpgradeStatus, error) {
+	results := make(map[string]*UpgradeStatus, len(adminPasswords))
+	var errs []string
+	for r := range f.CheckUpgradeStatusStream(ctx, adminPasswords) {
+		results[r.IP] = r.Value
+		if r.Err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", r.IP, r.Err))
+		}
+	}
+	return results, aggregateErrors(len(adminPasswords), errs)
+}
@@ -0,0 +1,66 @@
+package ssh
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"regexp"
+)
+
+// discardLogger is the default Client logger until WithLogger is called, so
+// every log call site stays safe even when nobody's configured one.
+var discardLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+// WithLogger sets the logger Client uses for per-command DEBUG output and
+// per-operation trace spans, and returns c for chaining. A nil logger
+// restores the discard default.
+func (c *Client) WithLogger(logger *slog.Logger) *Client {
+	if logger == nil {
+		logger = discardLogger
+	}
+	c.logger = logger
+	return c
+}
+
+// opLogger derives a logger for one high-level operation against ip, carrying
+// a fresh trace_id that ties together every sub-command it runs. Only
+// RunUpgradeLatest, EnableFeatures and AddToFleetManager use this - the rest
+// of Client's methods still log through runCommand's untraced per-command
+// DEBUG line, since threading a trace_id through all 25+ call sites isn't
+// worth the diff for operations nobody asked to trace.
+func (c *Client) opLogger(ip, operation string) *slog.Logger {
+	return c.logger.With("sensor_ip", ip, "operation", operation, "trace_id", newTraceID())
+}
+
+// newTraceID returns a short random hex ID to correlate the log lines of a
+// single operation.
+func newTraceID() string {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(b[:])
+}
+
+// passwordFlag matches the "-p <token>" admin password argument that
+// corelight-client commands embed inline, so it can be masked before a
+// command is ever logged.
+var passwordFlag = regexp.MustCompile(`(-p\s+)\S+`)
+
+// redactCommand masks admin password values embedded in command strings
+// (corelight-client's "-p <password>" flag) before they're logged.
+func redactCommand(cmd string) string {
+	return passwordFlag.ReplaceAllString(cmd, "${1}***")
+}
+
+// RedactAttr is a slog.HandlerOptions.ReplaceAttr function that scrubs
+// sensitive values - admin and SSH passwords - from log output, for callers
+// building the *slog.Logger passed to Client.WithLogger.
+func RedactAttr(groups []string, a slog.Attr) slog.Attr {
+	switch a.Key {
+	case "admin_password", "ssh_password", "password":
+		a.Value = slog.StringValue("***")
+	}
+	return a
+}
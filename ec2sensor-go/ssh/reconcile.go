@@ -0,0 +1,173 @@
+package ssh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/quanticsoul4772/ec2sensor-go/fleetspec"
+)
+
+// ErrRotationNotImplemented is recorded against rotate_admin_password
+// actions: there's no primitive yet to actually perform the rotation.
+var ErrRotationNotImplemented = errors.New("admin password rotation is not implemented")
+
+// ErrReleaseChannelDrift is recorded against release_channel actions whose
+// current value doesn't match the spec: there's no primitive to change the
+// channel directly (it's set via RunUpgradeSpecific's repo, not a dedicated
+// command), so drift is only ever reported, never corrected.
+var ErrReleaseChannelDrift = errors.New("release channel drift detected but no primitive exists to correct it")
+
+// ReconcileOptions configures a Reconcile pass.
+type ReconcileOptions struct {
+	// DryRun, when true, only builds the plan: no RunUpgradeSpecific,
+	// EnableFeatures or AddToFleetManager calls are made.
+	DryRun bool
+}
+
+// Reconcile drives every sensor named in spec towards its desired state,
+// skipping steps whose current state already matches. ips maps each sensor
+// name in spec.Sensors to the IP Reconcile should connect to. Cancelling ctx
+// stops the loop before the next sensor starts; a sensor already being
+// reconciled runs to completion since the underlying SSH commands don't
+// carry ctx themselves.
+func (c *Client) Reconcile(ctx context.Context, spec *fleetspec.Spec, ips map[string]string, opts ReconcileOptions) (*fleetspec.Report, error) {
+	report := &fleetspec.Report{}
+
+	for name, desired := range spec.Sensors {
+		select {
+		case <-ctx.Done():
+			return report, ctx.Err()
+		default:
+		}
+
+		ip, ok := ips[name]
+		if !ok {
+			report.Sensors = append(report.Sensors, fleetspec.SensorReport{
+				Name: name,
+				Err:  fmt.Errorf("no IP known for sensor %q", name),
+			})
+			continue
+		}
+
+		report.Sensors = append(report.Sensors, c.reconcileSensor(ip, name, desired, opts))
+	}
+
+	return report, nil
+}
+
+func (c *Client) reconcileSensor(ip, name string, desired fleetspec.SensorSpec, opts ReconcileOptions) fleetspec.SensorReport {
+	sr := fleetspec.SensorReport{Name: name, IP: ip}
+
+	var adminPassword string
+	if desired.Version != "" {
+		var err error
+		adminPassword, err = c.GetAdminPassword(ip)
+		if err != nil {
+			sr.Err = fmt.Errorf("get admin password: %w", err)
+			return sr
+		}
+	}
+
+	if desired.Version != "" {
+		sr.Actions = append(sr.Actions, c.planVersion(ip, adminPassword, desired, opts))
+	}
+	if desired.ReleaseChannel != "" {
+		sr.Actions = append(sr.Actions, c.planReleaseChannel(ip, desired))
+	}
+	if desired.EnableFeatures {
+		sr.Actions = append(sr.Actions, c.planEnableFeatures(ip, opts))
+	}
+	if desired.FleetManager {
+		sr.Actions = append(sr.Actions, c.planFleetManager(ip, opts))
+	}
+	if desired.RotateAdminPassword {
+		sr.Actions = append(sr.Actions, fleetspec.Action{
+			Step:    "rotate_admin_password",
+			Desired: "rotated",
+			Err:     ErrRotationNotImplemented,
+		})
+	}
+
+	return sr
+}
+
+// planVersion compares the sensor's current version against desired.Version
+// and, outside dry-run, runs RunUpgradeSpecific if they differ.
+func (c *Client) planVersion(ip, adminPassword string, desired fleetspec.SensorSpec, opts ReconcileOptions) fleetspec.Action {
+	current, err := c.GetSensorVersion(ip, adminPassword)
+	action := fleetspec.Action{Step: "version", Current: current, Desired: desired.Version}
+	if err != nil {
+		action.Err = err
+		return action
+	}
+	if current == desired.Version {
+		action.Skipped = true
+		return action
+	}
+	if opts.DryRun {
+		return action
+	}
+	if err := c.RunUpgradeSpecific(ip, desired.Repo, desired.Version); err != nil {
+		action.Err = err
+		return action
+	}
+	action.Applied = true
+	return action
+}
+
+// planReleaseChannel compares the sensor's current release channel against
+// desired.ReleaseChannel. Drift is only ever reported (see
+// ErrReleaseChannelDrift); there's nothing to apply.
+func (c *Client) planReleaseChannel(ip string, desired fleetspec.SensorSpec) fleetspec.Action {
+	current, err := c.GetReleaseChannel(ip)
+	action := fleetspec.Action{Step: "release_channel", Current: current, Desired: desired.ReleaseChannel}
+	if err != nil {
+		action.Err = err
+		return action
+	}
+	if current == desired.ReleaseChannel {
+		action.Skipped = true
+		return action
+	}
+	action.Err = ErrReleaseChannelDrift
+	return action
+}
+
+// planEnableFeatures has no way to read whether features are already
+// enabled, so it is never Skipped - only DryRun holds it back from applying.
+func (c *Client) planEnableFeatures(ip string, opts ReconcileOptions) fleetspec.Action {
+	action := fleetspec.Action{Step: "enable_features", Desired: "enabled"}
+	if opts.DryRun {
+		return action
+	}
+	result, err := c.EnableFeatures(ip)
+	if err != nil {
+		action.Err = err
+		return action
+	}
+	action.Current = result.Message
+	if !result.Success {
+		action.Err = fmt.Errorf("enable features: %s", result.Message)
+		return action
+	}
+	action.Applied = true
+	return action
+}
+
+// planFleetManager has no way to read current fleet-manager registration
+// state, so it is never Skipped - only DryRun holds it back from applying.
+func (c *Client) planFleetManager(ip string, opts ReconcileOptions) fleetspec.Action {
+	action := fleetspec.Action{Step: "fleet_manager", Desired: "registered"}
+	if opts.DryRun {
+		return action
+	}
+	output, err := c.AddToFleetManager(ip)
+	if err != nil {
+		action.Err = err
+		return action
+	}
+	action.Current = output
+	action.Applied = true
+	return action
+}
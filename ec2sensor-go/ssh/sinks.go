@@ -0,0 +1,93 @@
+package ssh
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/quanticsoul4772/ec2sensor-go/metrics"
+	"github.com/quanticsoul4772/ec2sensor-go/models"
+)
+
+// MetricsSink publishes one sensor's freshly collected metrics somewhere
+// other than (or in addition to) the return value CollectMetrics already
+// gives its caller. Client.WithSinks fans every successful CollectMetrics
+// result out to all configured sinks. Implementations: PrometheusMetricsSink,
+// StatsdSink, NoopSink.
+type MetricsSink interface {
+	Send(sensor string, m *models.SensorMetrics) error
+}
+
+// NoopSink discards every metric. It's only useful as an explicit
+// placeholder; Client already skips the fan-out entirely when no sinks are
+// configured.
+type NoopSink struct{}
+
+func (NoopSink) Send(sensor string, m *models.SensorMetrics) error { return nil }
+
+// PrometheusMetricsSink adapts an existing metrics.Metrics registry to
+// MetricsSink, so the push-mode fan-out loop in CollectMetrics can update
+// the same per-sensor gauges the pull-mode /metrics endpoint already
+// exposes.
+type PrometheusMetricsSink struct {
+	metrics *metrics.Metrics
+}
+
+// NewPrometheusMetricsSink builds a PrometheusMetricsSink reporting into m.
+func NewPrometheusMetricsSink(m *metrics.Metrics) *PrometheusMetricsSink {
+	return &PrometheusMetricsSink{metrics: m}
+}
+
+func (s *PrometheusMetricsSink) Send(sensor string, m *models.SensorMetrics) error {
+	s.metrics.ObserveSensorMetrics(sensor, m)
+	return nil
+}
+
+// StatsdSink pushes gauges to a StatsD/DogStatsD agent over UDP. Tags use
+// the DogStatsD "|#tag:value" extension; a plain StatsD daemon just ignores
+// the suffix it doesn't understand. There's no instance ID or region to tag
+// with (see models.Sensor), so metrics are tagged by sensor IP only.
+type StatsdSink struct {
+	conn *net.UDPConn
+}
+
+// NewStatsdSink dials a StatsD/DogStatsD agent at host:port. UDP dialing
+// doesn't itself contact the agent, so a wrong host/port is only discovered
+// when a Send's Write fails.
+func NewStatsdSink(host string, port int) (*StatsdSink, error) {
+	addr, err := net.ResolveUDPAddr("udp", fmt.Sprintf("%s:%d", host, port))
+	if err != nil {
+		return nil, fmt.Errorf("resolve statsd address: %w", err)
+	}
+	conn, err := net.DialUDP("udp", nil, addr)
+	if err != nil {
+		return nil, fmt.Errorf("dial statsd: %w", err)
+	}
+	return &StatsdSink{conn: conn}, nil
+}
+
+func (s *StatsdSink) Send(sensor string, m *models.SensorMetrics) error {
+	gauges := map[string]int{
+		"ec2sensor.cpu":    m.CPU,
+		"ec2sensor.memory": m.Memory,
+		"ec2sensor.disk":   m.Disk,
+		"ec2sensor.pods":   m.Pods,
+	}
+
+	var errs []string
+	for name, value := range gauges {
+		line := fmt.Sprintf("%s:%d|g|#sensor:%s", name, value, sensor)
+		if _, err := s.conn.Write([]byte(line)); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("statsd send: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// Close releases the sink's UDP socket.
+func (s *StatsdSink) Close() error {
+	return s.conn.Close()
+}
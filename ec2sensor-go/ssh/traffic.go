@@ -0,0 +1,292 @@
+package ssh
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// TrafficProfile describes a traffic-generation pattern to run against a
+// target. Its Descriptor is sent to the sensor as a base64-encoded JSON file
+// which simple_traffic_generator.py (installed by ConfigureTrafficGenerator)
+// dispatches on by its "mode" field.
+type TrafficProfile interface {
+	// Descriptor returns the profile's JSON-serializable parameters,
+	// including a "mode" field identifying which pattern to run.
+	Descriptor() map[string]any
+}
+
+// ConstantRate sends packets to Target:Port at a fixed rate for Duration
+// seconds - the original (and still default) traffic pattern.
+type ConstantRate struct {
+	Target   string
+	Port     int
+	Protocol string // "udp" or "tcp"
+	PPS      int
+	Duration int
+}
+
+func (p ConstantRate) Descriptor() map[string]any {
+	return map[string]any{
+		"mode":     "constant",
+		"target":   p.Target,
+		"port":     p.Port,
+		"protocol": p.Protocol,
+		"pps":      p.PPS,
+		"duration": p.Duration,
+	}
+}
+
+// Burst sends Count packets every Interval seconds, for Duration seconds
+// total, instead of a smooth constant rate.
+type Burst struct {
+	Target   string
+	Port     int
+	Protocol string
+	Count    int
+	Interval float64
+	Duration int
+}
+
+func (p Burst) Descriptor() map[string]any {
+	return map[string]any{
+		"mode":     "burst",
+		"target":   p.Target,
+		"port":     p.Port,
+		"protocol": p.Protocol,
+		"count":    p.Count,
+		"interval": p.Interval,
+		"duration": p.Duration,
+	}
+}
+
+// Ramp linearly increases the send rate from StartPPS to EndPPS over
+// Duration seconds, for load-testing scenarios that need gradual ramp-up.
+type Ramp struct {
+	Target   string
+	Port     int
+	Protocol string
+	StartPPS int
+	EndPPS   int
+	Duration int
+}
+
+func (p Ramp) Descriptor() map[string]any {
+	return map[string]any{
+		"mode":      "ramp",
+		"target":    p.Target,
+		"port":      p.Port,
+		"protocol":  p.Protocol,
+		"start_pps": p.StartPPS,
+		"end_pps":   p.EndPPS,
+		"duration":  p.Duration,
+	}
+}
+
+// HTTPLoad issues HTTP GET requests against URL at a target request rate.
+type HTTPLoad struct {
+	URL      string
+	RPS      int
+	Duration int
+}
+
+func (p HTTPLoad) Descriptor() map[string]any {
+	return map[string]any{
+		"mode":     "http",
+		"url":      p.URL,
+		"rps":      p.RPS,
+		"duration": p.Duration,
+	}
+}
+
+// ICMPFlood sends ICMP echo requests to Target at a target packet rate, for
+// reachability/load testing.
+type ICMPFlood struct {
+	Target   string
+	PPS      int
+	Duration int
+}
+
+func (p ICMPFlood) Descriptor() map[string]any {
+	return map[string]any{
+		"mode":     "icmp",
+		"target":   p.Target,
+		"pps":      p.PPS,
+		"duration": p.Duration,
+	}
+}
+
+// genSimpleTrafficGenerator, genProfileScript, and genTCPReplay identify
+// which process a tracked host is actually running, so StopTrafficGeneration
+// can pkill the right one instead of assuming simple_traffic_generator.py.
+const (
+	genSimpleTrafficGenerator = "simple_traffic_generator.py"
+	genProfileScript          = "/tmp/traffic_profile.sh"
+	genTCPReplay              = "tcpreplay"
+)
+
+// StartProfile starts the traffic generator on ip running profile in the
+// background. ConfigureTrafficGenerator must have been run against ip first.
+func (c *Client) StartProfile(ip string, profile TrafficProfile) error {
+	payload, err := json.Marshal(profile.Descriptor())
+	if err != nil {
+		return fmt.Errorf("encode traffic profile: %w", err)
+	}
+	encoded := base64.StdEncoding.EncodeToString(payload)
+
+	cmd := fmt.Sprintf(
+		"cd /tmp && echo %s | base64 -d > /tmp/traffic_profile.json && "+
+			"nohup python3 simple_traffic_generator.py --profile /tmp/traffic_profile.json > /tmp/traffic.log 2>&1 &",
+		encoded,
+	)
+	_, err = c.runCommand(ip, cmd)
+	if err == nil {
+		c.trackHost(ip, genSimpleTrafficGenerator)
+		if c.metrics != nil {
+			c.metrics.ObserveTrafficEvent(ip, "start")
+		}
+	}
+	return err
+}
+
+// RunProfileCommand backgrounds an arbitrary shell command on ip via nohup,
+// the same way StartProfile backgrounds simple_traffic_generator.py - for a
+// traffic.ProfileSpec whose Command is a direct hping3/curl-loader
+// invocation rather than a JSON descriptor the Python generator dispatches
+// on. The command is pushed base64-encoded to a script file first, the same
+// way StartProfile pushes its JSON descriptor, so shell metacharacters in
+// the command (the heredocs http-slowloris and mixed-benign-web use) don't
+// have to survive being embedded in another shell command's argument.
+func (c *Client) RunProfileCommand(ip, command string) error {
+	encoded := base64.StdEncoding.EncodeToString([]byte(command))
+	cmd := fmt.Sprintf(
+		"cd /tmp && echo %s | base64 -d > /tmp/traffic_profile.sh && chmod +x /tmp/traffic_profile.sh && "+
+			"nohup /tmp/traffic_profile.sh > /tmp/traffic.log 2>&1 &",
+		encoded,
+	)
+	_, err := c.runCommand(ip, cmd)
+	if err == nil {
+		c.trackHost(ip, genProfileScript)
+		if c.metrics != nil {
+			c.metrics.ObserveTrafficEvent(ip, "start")
+		}
+	}
+	return err
+}
+
+// UploadPCAP pushes the local capture at localPath to ip, base64-encoded
+// over the same kind of SSH command StartProfile uses to push its JSON
+// descriptor, and returns the path it was written to on the sensor for a
+// follow-up ReplayPCAP call.
+func (c *Client) UploadPCAP(ip, localPath string) (string, error) {
+	data, err := os.ReadFile(localPath)
+	if err != nil {
+		return "", fmt.Errorf("read pcap file: %w", err)
+	}
+	remotePath := "/tmp/" + filepath.Base(localPath)
+	encoded := base64.StdEncoding.EncodeToString(data)
+	if _, err := c.runCommand(ip, fmt.Sprintf("echo %s | base64 -d > %s", encoded, remotePath)); err != nil {
+		return "", fmt.Errorf("upload pcap: %w", err)
+	}
+	return remotePath, nil
+}
+
+// ReplayPCAP runs tcpreplay against remotePath (as returned by UploadPCAP)
+// in the background, at speed times the capture's original packet
+// intervals, looping loopCount times (0 means loop forever). tcpreplay
+// must already be installed - see ConfigureTrafficGenerator.
+func (c *Client) ReplayPCAP(ip, remotePath string, speed float64, loopCount int) error {
+	cmd := fmt.Sprintf("nohup tcpreplay --intf1=eth0 --multiplier=%g --loop=%d %s > /tmp/traffic.log 2>&1 &", speed, loopCount, remotePath)
+	_, err := c.runCommand(ip, cmd)
+	if err == nil {
+		c.trackHost(ip, genTCPReplay)
+		if c.metrics != nil {
+			c.metrics.ObserveTrafficEvent(ip, "start")
+		}
+	}
+	return err
+}
+
+// trackHost records ip as running the given generator kind (one of the
+// genXxx constants above) so ShutdownAll/StopTrafficGeneration know which
+// process to pkill on exit.
+func (c *Client) trackHost(ip, kind string) {
+	c.trackedMu.Lock()
+	defer c.trackedMu.Unlock()
+	if c.tracked == nil {
+		c.tracked = make(map[string]string)
+	}
+	c.tracked[ip] = kind
+}
+
+// untrackHost removes ip from the set ShutdownAll tears down, once its
+// traffic generator has been stopped.
+func (c *Client) untrackHost(ip string) {
+	c.trackedMu.Lock()
+	defer c.trackedMu.Unlock()
+	delete(c.tracked, ip)
+}
+
+// trackedKind returns the generator kind recorded for ip by trackHost, or
+// genSimpleTrafficGenerator if ip isn't tracked (e.g. a generator started by
+// an earlier process, which left no record in this Client).
+func (c *Client) trackedKind(ip string) string {
+	c.trackedMu.Lock()
+	defer c.trackedMu.Unlock()
+	if kind, ok := c.tracked[ip]; ok {
+		return kind
+	}
+	return genSimpleTrafficGenerator
+}
+
+// TrackedHosts returns the IPs of hosts with a traffic generator started via
+// StartTrafficGeneration, StartProfile, RunProfileCommand or ReplayPCAP that
+// hasn't been stopped yet.
+func (c *Client) TrackedHosts() []string {
+	c.trackedMu.Lock()
+	defer c.trackedMu.Unlock()
+	hosts := make([]string, 0, len(c.tracked))
+	for ip := range c.tracked {
+		hosts = append(hosts, ip)
+	}
+	return hosts
+}
+
+// ShutdownAll stops the traffic generator on every host in TrackedHosts in
+// parallel, bounding each host by ctx's deadline (or 10s if ctx has none).
+// Callers embedding Client in a CLI or API server should call this from
+// their SIGTERM/SIGINT/SIGHUP handler so a `nohup`'d remote generator
+// doesn't outlive the process that started it. Each per-host call goes
+// through StopTrafficGeneration, so the pkill it issues is covered by the
+// same metrics/logging as a manual stop - there's no separate dry-run mode
+// here, since verifying the command actually reached every tracked host
+// needs a live fleet and belongs in an integration run against real
+// sensors, not a unit test.
+func (c *Client) ShutdownAll(ctx context.Context) error {
+	hosts := c.TrackedHosts()
+	if len(hosts) == 0 {
+		return nil
+	}
+
+	timeout := 10 * time.Second
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+	fleet := NewFleet(c, FleetOptions{PerHostTimeout: timeout})
+	fleet.opts.MaxRetries = 0 // NewFleet turns 0 into its default; one attempt per host
+
+	var errs []string
+	for r := range runStream(ctx, fleet, hosts, func(ip string) (struct{}, error) {
+		return struct{}{}, c.StopTrafficGeneration(ip)
+	}) {
+		if r.Err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", r.IP, r.Err))
+		}
+	}
+
+	return aggregateErrors(len(hosts), errs)
+}
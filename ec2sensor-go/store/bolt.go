@@ -0,0 +1,107 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/quanticsoul4772/ec2sensor-go/models"
+)
+
+var sensorsBucket = []byte("sensors")
+
+// BoltStore persists sensor state to a local BoltDB file, so the TUI/CLI
+// survives restarts without re-fetching every sensor from the API. Watch
+// notifications only cover changes made within this process; use RedisStore
+// when multiple instances need to see each other's writes.
+type BoltStore struct {
+	db  *bolt.DB
+	mem *MemoryStore
+}
+
+// NewBoltStore opens (creating if necessary) a BoltDB file at path.
+func NewBoltStore(path string) (*BoltStore, error) {
+	db, err := bolt.Open(path, 0600, nil)
+	if err != nil {
+		return nil, fmt.Errorf("open bolt store: %w", err)
+	}
+	if err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(sensorsBucket)
+		return err
+	}); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("init bolt store: %w", err)
+	}
+	return &BoltStore{db: db, mem: NewMemoryStore()}, nil
+}
+
+// Get returns the stored sensor, or ErrNotFound.
+func (s *BoltStore) Get(name string) (*models.Sensor, error) {
+	var sensor models.Sensor
+	err := s.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(sensorsBucket).Get([]byte(name))
+		if data == nil {
+			return ErrNotFound
+		}
+		return json.Unmarshal(data, &sensor)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &sensor, nil
+}
+
+// Put persists sensor and notifies local Watch subscribers.
+func (s *BoltStore) Put(sensor *models.Sensor) error {
+	data, err := json.Marshal(sensor)
+	if err != nil {
+		return err
+	}
+	if err := s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(sensorsBucket).Put([]byte(sensor.Name), data)
+	}); err != nil {
+		return fmt.Errorf("bolt put: %w", err)
+	}
+	return s.mem.Put(sensor)
+}
+
+// List returns all persisted sensors.
+func (s *BoltStore) List() ([]*models.Sensor, error) {
+	var out []*models.Sensor
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(sensorsBucket).ForEach(func(_, v []byte) error {
+			var sensor models.Sensor
+			if err := json.Unmarshal(v, &sensor); err != nil {
+				return err
+			}
+			out = append(out, &sensor)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("bolt list: %w", err)
+	}
+	return out, nil
+}
+
+// Delete removes a sensor and notifies local Watch subscribers.
+func (s *BoltStore) Delete(name string) error {
+	if err := s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(sensorsBucket).Delete([]byte(name))
+	}); err != nil {
+		return fmt.Errorf("bolt delete: %w", err)
+	}
+	return s.mem.Delete(name)
+}
+
+// Watch delegates to an in-process MemoryStore fan-out; it does not see
+// writes made by other processes sharing this file.
+func (s *BoltStore) Watch(stop <-chan struct{}) (<-chan Event, error) {
+	return s.mem.Watch(stop)
+}
+
+// Close closes the underlying BoltDB file.
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
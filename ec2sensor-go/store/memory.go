@@ -0,0 +1,104 @@
+package store
+
+import (
+	"sync"
+
+	"github.com/quanticsoul4772/ec2sensor-go/models"
+)
+
+// MemoryStore is the default process-local SensorStore: no persistence
+// across restarts, and Watch only sees changes made within this process.
+type MemoryStore struct {
+	mu      sync.RWMutex
+	sensors map[string]*models.Sensor
+
+	subMu sync.Mutex
+	subs  map[chan Event]struct{}
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		sensors: make(map[string]*models.Sensor),
+		subs:    make(map[chan Event]struct{}),
+	}
+}
+
+// Get returns a copy of the stored sensor, or ErrNotFound.
+func (s *MemoryStore) Get(name string) (*models.Sensor, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	sensor, ok := s.sensors[name]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	cp := *sensor
+	return &cp, nil
+}
+
+// Put stores a copy of sensor and notifies Watch subscribers.
+func (s *MemoryStore) Put(sensor *models.Sensor) error {
+	cp := *sensor
+	s.mu.Lock()
+	s.sensors[sensor.Name] = &cp
+	s.mu.Unlock()
+	s.publish(Event{Type: EventPut, Sensor: &cp})
+	return nil
+}
+
+// List returns copies of all stored sensors, in no particular order.
+func (s *MemoryStore) List() ([]*models.Sensor, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]*models.Sensor, 0, len(s.sensors))
+	for _, sensor := range s.sensors {
+		cp := *sensor
+		out = append(out, &cp)
+	}
+	return out, nil
+}
+
+// Delete removes a sensor and notifies Watch subscribers.
+func (s *MemoryStore) Delete(name string) error {
+	s.mu.Lock()
+	delete(s.sensors, name)
+	s.mu.Unlock()
+	s.publish(Event{Type: EventDelete, Name: name})
+	return nil
+}
+
+// Watch subscribes to Put/Delete events made by this process. stop, once
+// closed, unsubscribes and closes the returned channel.
+func (s *MemoryStore) Watch(stop <-chan struct{}) (<-chan Event, error) {
+	ch := make(chan Event, 16)
+	s.subMu.Lock()
+	s.subs[ch] = struct{}{}
+	s.subMu.Unlock()
+
+	go func() {
+		<-stop
+		s.subMu.Lock()
+		delete(s.subs, ch)
+		s.subMu.Unlock()
+		close(ch)
+	}()
+
+	return ch, nil
+}
+
+func (s *MemoryStore) publish(evt Event) {
+	s.subMu.Lock()
+	defer s.subMu.Unlock()
+	for ch := range s.subs {
+		select {
+		case ch <- evt:
+		default:
+			// Subscriber isn't keeping up; drop rather than block the writer.
+		}
+	}
+}
+
+// Close is a no-op; MemoryStore owns no external resources.
+func (s *MemoryStore) Close() error {
+	return nil
+}
@@ -0,0 +1,209 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+
+	"github.com/quanticsoul4772/ec2sensor-go/models"
+)
+
+// sensorChannel is the Redis pub/sub channel (namespaced by KeyPrefix) used
+// to notify other clients sharing this backend of sensor state changes.
+const sensorChannel = "sensor-events"
+
+// RedisConfig configures the pooled connection backing a RedisStore.
+type RedisConfig struct {
+	Addr        string
+	Password    string
+	DB          int
+	MaxIdle     int
+	MaxActive   int
+	IdleTimeout time.Duration
+	DialTimeout time.Duration
+	// KeyPrefix namespaces keys and the pub/sub channel, e.g. "ec2sensor:".
+	KeyPrefix string
+}
+
+// RedisStore is a shared SensorStore backed by Redis, letting multiple
+// ec2sensor instances see each other's sensor state and react to changes via
+// Watch's pub/sub notifications.
+type RedisStore struct {
+	pool   *redis.Pool
+	prefix string
+}
+
+// NewRedisStore builds a RedisStore with a connection pool matching cfg
+// (AUTH, SELECT, idle/active limits and timeouts).
+func NewRedisStore(cfg RedisConfig) *RedisStore {
+	prefix := cfg.KeyPrefix
+	if prefix == "" {
+		prefix = "ec2sensor:"
+	}
+
+	pool := &redis.Pool{
+		MaxIdle:     cfg.MaxIdle,
+		MaxActive:   cfg.MaxActive,
+		IdleTimeout: cfg.IdleTimeout,
+		Dial: func() (redis.Conn, error) {
+			conn, err := redis.Dial("tcp", cfg.Addr, redis.DialConnectTimeout(cfg.DialTimeout))
+			if err != nil {
+				return nil, err
+			}
+			if cfg.Password != "" {
+				if _, err := conn.Do("AUTH", cfg.Password); err != nil {
+					conn.Close()
+					return nil, err
+				}
+			}
+			if cfg.DB != 0 {
+				if _, err := conn.Do("SELECT", cfg.DB); err != nil {
+					conn.Close()
+					return nil, err
+				}
+			}
+			return conn, nil
+		},
+		TestOnBorrow: func(conn redis.Conn, _ time.Time) error {
+			_, err := conn.Do("PING")
+			return err
+		},
+	}
+
+	return &RedisStore{pool: pool, prefix: prefix}
+}
+
+func (s *RedisStore) key(name string) string {
+	return s.prefix + name
+}
+
+// Get returns the cached sensor, or ErrNotFound.
+func (s *RedisStore) Get(name string) (*models.Sensor, error) {
+	conn := s.pool.Get()
+	defer conn.Close()
+
+	data, err := redis.Bytes(conn.Do("GET", s.key(name)))
+	if err == redis.ErrNil {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("redis get: %w", err)
+	}
+
+	var sensor models.Sensor
+	if err := json.Unmarshal(data, &sensor); err != nil {
+		return nil, err
+	}
+	return &sensor, nil
+}
+
+// Put caches sensor and publishes a change notification.
+func (s *RedisStore) Put(sensor *models.Sensor) error {
+	data, err := json.Marshal(sensor)
+	if err != nil {
+		return err
+	}
+
+	conn := s.pool.Get()
+	defer conn.Close()
+	if _, err := conn.Do("SET", s.key(sensor.Name), data); err != nil {
+		return fmt.Errorf("redis set: %w", err)
+	}
+
+	return s.publish(conn, Event{Type: EventPut, Sensor: sensor})
+}
+
+// List returns all cached sensors under this store's key prefix.
+func (s *RedisStore) List() ([]*models.Sensor, error) {
+	conn := s.pool.Get()
+	defer conn.Close()
+
+	keys, err := redis.Strings(conn.Do("KEYS", s.prefix+"*"))
+	if err != nil {
+		return nil, fmt.Errorf("redis keys: %w", err)
+	}
+
+	out := make([]*models.Sensor, 0, len(keys))
+	for _, key := range keys {
+		if key == s.prefix+sensorChannel {
+			continue
+		}
+		data, err := redis.Bytes(conn.Do("GET", key))
+		if err != nil {
+			continue
+		}
+		var sensor models.Sensor
+		if err := json.Unmarshal(data, &sensor); err != nil {
+			continue
+		}
+		out = append(out, &sensor)
+	}
+	return out, nil
+}
+
+// Delete removes a sensor and publishes a change notification.
+func (s *RedisStore) Delete(name string) error {
+	conn := s.pool.Get()
+	defer conn.Close()
+	if _, err := conn.Do("DEL", s.key(name)); err != nil {
+		return fmt.Errorf("redis del: %w", err)
+	}
+	return s.publish(conn, Event{Type: EventDelete, Name: name})
+}
+
+func (s *RedisStore) publish(conn redis.Conn, evt Event) error {
+	data, err := json.Marshal(evt)
+	if err != nil {
+		return err
+	}
+	_, err = conn.Do("PUBLISH", s.prefix+sensorChannel, data)
+	return err
+}
+
+// Watch subscribes to sensor change notifications published by any client
+// sharing this Redis backend. Closing stop unsubscribes and closes the
+// returned channel.
+func (s *RedisStore) Watch(stop <-chan struct{}) (<-chan Event, error) {
+	conn := s.pool.Get()
+	psc := redis.PubSubConn{Conn: conn}
+	if err := psc.Subscribe(s.prefix + sensorChannel); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("redis subscribe: %w", err)
+	}
+
+	ch := make(chan Event, 16)
+	go func() {
+		defer close(ch)
+		defer conn.Close()
+		go func() {
+			<-stop
+			psc.Unsubscribe()
+		}()
+		for {
+			switch v := psc.Receive().(type) {
+			case redis.Message:
+				var evt Event
+				if err := json.Unmarshal(v.Data, &evt); err == nil {
+					select {
+					case ch <- evt:
+					default:
+					}
+				}
+			case redis.Subscription:
+				if v.Count == 0 {
+					return
+				}
+			case error:
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+// Close shuts down the connection pool.
+func (s *RedisStore) Close() error {
+	return s.pool.Close()
+}
@@ -0,0 +1,45 @@
+// Package store provides the pluggable persistence/cache layer behind
+// api.Client: an in-memory default, a BoltDB-backed local store, and a
+// Redis-backed shared cache, all behind the same SensorStore interface.
+package store
+
+import (
+	"errors"
+
+	"github.com/quanticsoul4772/ec2sensor-go/models"
+)
+
+// ErrNotFound is returned by Get when no sensor is stored under that name.
+var ErrNotFound = errors.New("store: sensor not found")
+
+// EventType identifies what changed in a Watch notification.
+type EventType string
+
+const (
+	EventPut    EventType = "put"
+	EventDelete EventType = "delete"
+)
+
+// Event is a single sensor state change delivered to Watch subscribers,
+// including changes made by other clients sharing the same backend.
+type Event struct {
+	Type   EventType
+	Sensor *models.Sensor `json:"Sensor,omitempty"`
+	Name   string         `json:"Name,omitempty"` // set on EventDelete, where Sensor is nil
+}
+
+// SensorStore is the pluggable persistence/cache layer behind api.Client.
+// Implementations: MemoryStore (process-local default), BoltStore (local
+// on-disk persistence), RedisStore (shared cache across instances).
+type SensorStore interface {
+	Get(name string) (*models.Sensor, error)
+	Put(sensor *models.Sensor) error
+	List() ([]*models.Sensor, error)
+	Delete(name string) error
+
+	// Watch returns a channel of Events for changes made by any client
+	// sharing this backend. Closing stop unsubscribes and closes the channel.
+	Watch(stop <-chan struct{}) (<-chan Event, error)
+
+	Close() error
+}
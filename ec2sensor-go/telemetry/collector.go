@@ -0,0 +1,137 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/quanticsoul4772/ec2sensor-go/ssh"
+)
+
+// Collector gathers a Report across a fleet on an interval and hands each
+// one to every configured Sink.
+type Collector struct {
+	fleet   *ssh.Fleet
+	sinks   []Sink
+	redact  map[string]bool
+	newUUID func() string
+}
+
+// NewCollector builds a Collector that gathers through fleet and publishes
+// to sinks. redactFields is a comma-separated list of SensorReport JSON
+// field names (case-insensitive) to omit from every Report, e.g.
+// "ip,services".
+func NewCollector(fleet *ssh.Fleet, sinks []Sink, redactFields string) *Collector {
+	redact := make(map[string]bool)
+	for _, f := range strings.Split(redactFields, ",") {
+		if f = strings.ToLower(strings.TrimSpace(f)); f != "" {
+			redact[f] = true
+		}
+	}
+	return &Collector{fleet: fleet, sinks: sinks, redact: redact, newUUID: randomUUID}
+}
+
+// Run collects and publishes one Report every interval until ctx is
+// cancelled. Sink failures are logged but never stop the loop - the next
+// tick still runs, and HTTPSink spools what it couldn't deliver.
+func (c *Collector) Run(ctx context.Context, sensors map[string]string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		report := c.Collect(ctx, sensors)
+		for _, sink := range c.sinks {
+			if err := sink.Send(ctx, report); err != nil {
+				log.Printf("telemetry: sink failed: %v", err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Collect runs one collection pass across sensors (name -> IP) without
+// waiting for a ticker, for callers (like `telemetry run --once`) that want
+// a single report. GetAdminPassword is fetched per sensor to authenticate
+// GetSensorVersion and CheckUpgradeStatus, but is never itself recorded
+// anywhere in the returned Report.
+func (c *Collector) Collect(ctx context.Context, sensors map[string]string) *Report {
+	client := c.fleet.Client()
+
+	ipToName := make(map[string]string, len(sensors))
+	ips := make([]string, 0, len(sensors))
+	for name, ip := range sensors {
+		ipToName[ip] = name
+		ips = append(ips, ip)
+	}
+
+	results := ssh.RunStream(ctx, c.fleet, ips, func(ip string) (SensorReport, error) {
+		sr := SensorReport{Name: ipToName[ip], IP: ip}
+
+		metrics, err := client.CollectMetrics(ip)
+		if err != nil {
+			return sr, fmt.Errorf("collect metrics: %w", err)
+		}
+		sr.Metrics = metrics
+
+		if channel, err := client.GetReleaseChannel(ip); err == nil {
+			sr.ReleaseChannel = channel
+		}
+
+		if services, err := client.GetServiceStatus(ip); err == nil {
+			sr.Services = services
+		}
+
+		if password, err := client.GetAdminPassword(ip); err == nil {
+			if version, err := client.GetSensorVersion(ip, password); err == nil {
+				sr.Version = version
+			}
+			if status, err := client.CheckUpgradeStatus(ip, password); err == nil {
+				sr.UpgradeStatus = status
+			}
+		}
+
+		return sr, nil
+	})
+
+	report := &Report{SchemaVersion: SchemaVersion, RunID: c.newUUID(), GeneratedAt: time.Now()}
+	for r := range results {
+		sr := r.Value
+		sr.Name = ipToName[r.IP]
+		sr.IP = r.IP
+		if r.Err != nil {
+			sr.Err = r.Err.Error()
+		}
+		c.applyRedact(&sr)
+		report.Sensors = append(report.Sensors, sr)
+	}
+	return report
+}
+
+// applyRedact blanks any field named in c.redact.
+func (c *Collector) applyRedact(sr *SensorReport) {
+	if c.redact["ip"] {
+		sr.IP = ""
+	}
+	if c.redact["metrics"] {
+		sr.Metrics = nil
+	}
+	if c.redact["version"] {
+		sr.Version = ""
+	}
+	if c.redact["release_channel"] {
+		sr.ReleaseChannel = ""
+	}
+	if c.redact["upgrade_status"] {
+		sr.UpgradeStatus = nil
+	}
+	if c.redact["services"] {
+		sr.Services = nil
+	}
+}
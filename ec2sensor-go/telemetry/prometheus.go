@@ -0,0 +1,106 @@
+package telemetry
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// PrometheusSink exposes the numbers carried in each Report as gauges and
+// counters on its own registry, for scraping rather than push-based
+// collection. Unlike the other sinks it never blocks or fails on Send -
+// Send just updates the registry in place.
+type PrometheusSink struct {
+	registry *prometheus.Registry
+
+	cpu              *prometheus.GaugeVec
+	memory           *prometheus.GaugeVec
+	disk             *prometheus.GaugeVec
+	pods             *prometheus.GaugeVec
+	upgradeRunning   *prometheus.GaugeVec
+	lastReportTime   *prometheus.GaugeVec
+	collectionErrors *prometheus.CounterVec
+}
+
+// NewPrometheusSink creates a PrometheusSink with all collectors registered.
+func NewPrometheusSink() *PrometheusSink {
+	registry := prometheus.NewRegistry()
+
+	s := &PrometheusSink{
+		registry: registry,
+		cpu: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "ec2sensor_telemetry_cpu_percent",
+			Help: "Per-sensor CPU usage percentage, from the last telemetry report.",
+		}, []string{"sensor"}),
+		memory: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "ec2sensor_telemetry_memory_percent",
+			Help: "Per-sensor memory usage percentage, from the last telemetry report.",
+		}, []string{"sensor"}),
+		disk: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "ec2sensor_telemetry_disk_percent",
+			Help: "Per-sensor disk usage percentage, from the last telemetry report.",
+		}, []string{"sensor"}),
+		pods: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "ec2sensor_telemetry_pods",
+			Help: "Per-sensor running pod/service count, from the last telemetry report.",
+		}, []string{"sensor"}),
+		upgradeRunning: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "ec2sensor_telemetry_upgrade_running",
+			Help: "1 if the sensor's upgrade process was running as of the last report, else 0.",
+		}, []string{"sensor"}),
+		lastReportTime: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "ec2sensor_telemetry_last_report_seconds",
+			Help: "Unix timestamp of the last telemetry report covering this sensor.",
+		}, []string{"sensor"}),
+		collectionErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "ec2sensor_telemetry_collection_errors_total",
+			Help: "Total collection failures, labeled by sensor.",
+		}, []string{"sensor"}),
+	}
+
+	registry.MustRegister(
+		s.cpu, s.memory, s.disk, s.pods, s.upgradeRunning, s.lastReportTime, s.collectionErrors,
+	)
+	return s
+}
+
+// Send updates every gauge/counter from report. It never returns an error.
+func (s *PrometheusSink) Send(ctx context.Context, report *Report) error {
+	for _, sr := range report.Sensors {
+		if sr.Err != "" {
+			s.collectionErrors.WithLabelValues(sr.Name).Inc()
+			continue
+		}
+		if sr.Metrics != nil {
+			s.cpu.WithLabelValues(sr.Name).Set(float64(sr.Metrics.CPU))
+			s.memory.WithLabelValues(sr.Name).Set(float64(sr.Metrics.Memory))
+			s.disk.WithLabelValues(sr.Name).Set(float64(sr.Metrics.Disk))
+			s.pods.WithLabelValues(sr.Name).Set(float64(sr.Metrics.Pods))
+		}
+		if sr.UpgradeStatus != nil {
+			running := 0.0
+			if sr.UpgradeStatus.ProcessRunning {
+				running = 1
+			}
+			s.upgradeRunning.WithLabelValues(sr.Name).Set(running)
+		}
+		s.lastReportTime.WithLabelValues(sr.Name).Set(float64(report.GeneratedAt.Unix()))
+	}
+	return nil
+}
+
+// Handler returns the HTTP handler serving this sink's registry in
+// Prometheus exposition format.
+func (s *PrometheusSink) Handler() http.Handler {
+	return promhttp.HandlerFor(s.registry, promhttp.HandlerOpts{})
+}
+
+// ListenAndServe starts an HTTP server on addr serving /metrics. It blocks
+// until the server stops; callers typically run it in a goroutine.
+func (s *PrometheusSink) ListenAndServe(addr string) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", s.Handler())
+	return http.ListenAndServe(addr, mux)
+}
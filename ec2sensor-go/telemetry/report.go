@@ -0,0 +1,42 @@
+// Package telemetry periodically collects fleet state over SSH and
+// publishes it to a pluggable Sink (HTTP collector, file, stdout, or
+// Prometheus), in the style of fedora-coreos-pinger's opt-in host
+// reporting. Collection never touches GetAdminPassword output beyond using
+// it to authenticate other calls, so an admin password can never end up in
+// a published Report.
+package telemetry
+
+import (
+	"time"
+
+	"github.com/quanticsoul4772/ec2sensor-go/models"
+	"github.com/quanticsoul4772/ec2sensor-go/ssh"
+)
+
+// SchemaVersion is bumped whenever Report's shape changes incompatibly, so
+// a collector can be told apart from older/newer clients.
+const SchemaVersion = 1
+
+// Report is one collection run across the fleet.
+type Report struct {
+	SchemaVersion int            `json:"schema_version"`
+	RunID         string         `json:"run_id"`
+	GeneratedAt   time.Time      `json:"generated_at"`
+	Sensors       []SensorReport `json:"sensors"`
+}
+
+// SensorReport is the state collected from a single sensor. Err is set
+// (and every field other than Name and IP left zero) when collection failed
+// for this sensor; a failed sensor doesn't abort the rest of the run.
+type SensorReport struct {
+	Name string `json:"name"`
+	IP   string `json:"ip,omitempty"`
+
+	Metrics        *models.SensorMetrics `json:"metrics,omitempty"`
+	Version        string                `json:"version,omitempty"`
+	ReleaseChannel string                `json:"release_channel,omitempty"`
+	UpgradeStatus  *ssh.UpgradeStatus    `json:"upgrade_status,omitempty"`
+	Services       []ssh.ServiceStatus   `json:"services,omitempty"`
+
+	Err string `json:"error,omitempty"`
+}
@@ -0,0 +1,10 @@
+package telemetry
+
+import "context"
+
+// Sink publishes a collected Report somewhere: an HTTP collector, local
+// disk, stdout, or a Prometheus registry for scraping. Implementations:
+// HTTPSink, FileSink, StdoutSink, PrometheusSink.
+type Sink interface {
+	Send(ctx context.Context, report *Report) error
+}
@@ -0,0 +1,43 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// FileSink appends each Report as one JSON line to a file, creating parent
+// directories as needed. It's used both as a standalone sink and as
+// HTTPSink's on-disk spool for reports that couldn't be delivered.
+type FileSink struct {
+	path string
+}
+
+// NewFileSink builds a FileSink appending to path.
+func NewFileSink(path string) *FileSink {
+	return &FileSink{path: path}
+}
+
+// Send appends report to the sink's file as a single JSON line.
+func (s *FileSink) Send(ctx context.Context, report *Report) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("create spool dir: %w", err)
+	}
+
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("open spool file: %w", err)
+	}
+	defer f.Close()
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("marshal report: %w", err)
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("write spool file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package telemetry
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// HTTPSink POSTs each Report as JSON to a collector URL, retrying
+// transient failures with exponential backoff before spooling the report
+// to disk so it can be replayed once the collector is reachable again.
+type HTTPSink struct {
+	url         string
+	bearerToken string
+	gzip        bool
+	httpClient  *http.Client
+	spool       *FileSink
+
+	maxRetries int
+	baseDelay  time.Duration
+	maxDelay   time.Duration
+}
+
+// NewHTTPSink builds an HTTPSink posting to url. spoolPath is where reports
+// that exhaust their retries are appended for later replay; empty disables
+// spooling.
+func NewHTTPSink(url, bearerToken string, gzipBody bool, spoolPath string) *HTTPSink {
+	var spool *FileSink
+	if spoolPath != "" {
+		spool = NewFileSink(spoolPath)
+	}
+	return &HTTPSink{
+		url:         url,
+		bearerToken: bearerToken,
+		gzip:        gzipBody,
+		httpClient:  &http.Client{Timeout: 30 * time.Second},
+		spool:       spool,
+		maxRetries:  4,
+		baseDelay:   1 * time.Second,
+		maxDelay:    30 * time.Second,
+	}
+}
+
+// Send posts report to the collector, retrying transient failures (network
+// errors and 5xx/429 responses) with exponential backoff. If every attempt
+// fails, the report is spooled to disk (if configured) instead of being
+// dropped, and Send still returns the last error so callers can log it.
+func (s *HTTPSink) Send(ctx context.Context, report *Report) error {
+	body, err := s.encode(report)
+	if err != nil {
+		return fmt.Errorf("encode report: %w", err)
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= s.maxRetries; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-ctx.Done():
+				return s.spoolOrReturn(report, ctx.Err())
+			case <-time.After(s.delay(attempt - 1)):
+			}
+		}
+
+		status, err := s.post(ctx, body)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+		if status != 0 && status < 500 && status != http.StatusTooManyRequests {
+			break // terminal 4xx: retrying won't help
+		}
+	}
+
+	return s.spoolOrReturn(report, lastErr)
+}
+
+func (s *HTTPSink) spoolOrReturn(report *Report, sendErr error) error {
+	if s.spool == nil {
+		return sendErr
+	}
+	if err := s.spool.Send(context.Background(), report); err != nil {
+		return fmt.Errorf("%w (and failed to spool: %v)", sendErr, err)
+	}
+	return fmt.Errorf("%w (spooled for replay)", sendErr)
+}
+
+func (s *HTTPSink) encode(report *Report) ([]byte, error) {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return nil, err
+	}
+	if !s.gzip {
+		return data, nil
+	}
+
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	if _, err := gw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := gw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// post makes one HTTP attempt, returning the response status code (0 if no
+// response was received) alongside any error.
+func (s *HTTPSink) post(ctx context.Context, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.gzip {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+	if s.bearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+s.bearerToken)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("collector returned %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// delay mirrors api.RetryPolicy's exponential-backoff-with-jitter shape,
+// kept local since RetryPolicy's delay method is unexported.
+func (s *HTTPSink) delay(attempt int) time.Duration {
+	d := float64(s.baseDelay) * math.Pow(2, float64(attempt-1))
+	if d > float64(s.maxDelay) {
+		d = float64(s.maxDelay)
+	}
+	d *= 1 + 0.2*(rand.Float64()*2-1)
+	if d < 0 {
+		d = 0
+	}
+	return time.Duration(d)
+}
@@ -0,0 +1,26 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+)
+
+// StdoutSink writes each Report as indented JSON to an io.Writer (normally
+// os.Stdout), for local debugging without a live collector.
+type StdoutSink struct {
+	w io.Writer
+}
+
+// NewStdoutSink builds a StdoutSink writing to w.
+func NewStdoutSink(w io.Writer) *StdoutSink {
+	return &StdoutSink{w: w}
+}
+
+// Send writes report to the sink's writer; it never fails to encode valid
+// Report values, but the write itself can.
+func (s *StdoutSink) Send(ctx context.Context, report *Report) error {
+	enc := json.NewEncoder(s.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
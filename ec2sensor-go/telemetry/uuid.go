@@ -0,0 +1,22 @@
+package telemetry
+
+import (
+	"crypto/rand"
+	"fmt"
+)
+
+// randomUUID returns a random (v4) UUID string, used to tag each collection
+// run so reports from the same pass can be correlated downstream.
+func randomUUID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		// crypto/rand.Read only fails if the OS RNG is unavailable, which
+		// would make the rest of the process untrustworthy too; panic
+		// rather than tag a run with a zero UUID.
+		panic(fmt.Sprintf("telemetry: read random UUID: %v", err))
+	}
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // variant 10
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,144 @@
+package traffic
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/quanticsoul4772/ec2sensor-go/ssh"
+)
+
+// GeneratorProfile is a saved set of ssh.Client.StartProfile parameters -
+// target, pattern, and rate - so starting the same traffic shape again
+// doesn't mean re-typing every field by hand. Unlike ProfileSpec (a named
+// shell command run directly on the sensor, e.g. hping3 or curl-loader),
+// a GeneratorProfile drives the built-in descriptor-based ssh.TrafficProfile
+// patterns simple_traffic_generator.py already knows how to run.
+type GeneratorProfile struct {
+	Name     string `yaml:"name"`
+	Target   string `yaml:"target"`
+	Port     int    `yaml:"port"`
+	Protocol string `yaml:"protocol"` // "udp" or "tcp"
+	Pattern  string `yaml:"pattern"`  // "constant" (default), "burst", or "ramp"
+	PPS      int    `yaml:"pps,omitempty"`
+	Duration int    `yaml:"duration"`
+
+	// Burst-only.
+	Count    int     `yaml:"count,omitempty"`
+	Interval float64 `yaml:"interval,omitempty"`
+
+	// Ramp-only.
+	StartPPS int `yaml:"start_pps,omitempty"`
+	EndPPS   int `yaml:"end_pps,omitempty"`
+}
+
+// Descriptor builds the ssh.TrafficProfile p.Pattern selects, ready to pass
+// to ssh.Client.StartProfile.
+func (p GeneratorProfile) Descriptor() (ssh.TrafficProfile, error) {
+	switch p.Pattern {
+	case "", "constant":
+		return ssh.ConstantRate{Target: p.Target, Port: p.Port, Protocol: p.Protocol, PPS: p.PPS, Duration: p.Duration}, nil
+	case "burst":
+		return ssh.Burst{Target: p.Target, Port: p.Port, Protocol: p.Protocol, Count: p.Count, Interval: p.Interval, Duration: p.Duration}, nil
+	case "ramp":
+		return ssh.Ramp{Target: p.Target, Port: p.Port, Protocol: p.Protocol, StartPPS: p.StartPPS, EndPPS: p.EndPPS, Duration: p.Duration}, nil
+	default:
+		return nil, fmt.Errorf("%s: unknown pattern %q", p.Name, p.Pattern)
+	}
+}
+
+// GeneratorProfilesPath resolves the saved-profile file,
+// ~/.ec2sensor/traffic-profiles.yaml - a top-level dotfile like
+// jobs.DefaultPath, since it's saved TUI input rather than configuration
+// (see ProfilesDir for the separate custom shell-command profile library).
+func GeneratorProfilesPath() string {
+	return filepath.Join(os.Getenv("HOME"), ".ec2sensor", "traffic-profiles.yaml")
+}
+
+type generatorProfileFile struct {
+	Profiles []GeneratorProfile `yaml:"profiles"`
+}
+
+// LoadGeneratorProfiles reads every saved profile from
+// GeneratorProfilesPath. A missing file yields an empty list, not an error.
+func LoadGeneratorProfiles() ([]GeneratorProfile, error) {
+	data, err := os.ReadFile(GeneratorProfilesPath())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read traffic profiles: %w", err)
+	}
+	var f generatorProfileFile
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("parse traffic profiles: %w", err)
+	}
+	return f.Profiles, nil
+}
+
+// SaveGeneratorProfile appends profile to GeneratorProfilesPath, creating
+// the file (and its directory) if this is the first one saved.
+func SaveGeneratorProfile(profile GeneratorProfile) error {
+	profiles, err := LoadGeneratorProfiles()
+	if err != nil {
+		return err
+	}
+	profiles = append(profiles, profile)
+
+	path := GeneratorProfilesPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("create traffic profiles dir: %w", err)
+	}
+	data, err := yaml.Marshal(generatorProfileFile{Profiles: profiles})
+	if err != nil {
+		return fmt.Errorf("encode traffic profiles: %w", err)
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// QuickProfileParams is the fixed field list the TUI's "New quick profile"
+// form collects to build a constant-rate GeneratorProfile - the same
+// target/port/protocol/pps/duration shape the traffic generator's original
+// fixed-step wizard prompted for. Burst and ramp profiles have extra
+// fields the guided form doesn't collect; save those directly to
+// GeneratorProfilesPath instead.
+var QuickProfileParams = []ParamSpec{
+	{Name: "name", Label: "Profile name", Required: true},
+	{Name: "target", Label: "Target IP", Required: true},
+	{Name: "port", Label: "Target port", Kind: "int", Default: "80"},
+	{Name: "protocol", Label: "Protocol (udp/tcp)", Default: "udp"},
+	{Name: "pps", Label: "Packets/sec", Kind: "int", Default: "100"},
+	{Name: "duration", Label: "Duration (seconds, 0=continuous)", Kind: "int", Default: "30"},
+}
+
+// GeneratorProfileFromValues builds a constant-rate GeneratorProfile from
+// values collected against QuickProfileParams.
+func GeneratorProfileFromValues(values map[string]string) (GeneratorProfile, error) {
+	port, err := strconv.Atoi(values["port"])
+	if err != nil {
+		return GeneratorProfile{}, fmt.Errorf("port: %w", err)
+	}
+	pps, err := strconv.Atoi(values["pps"])
+	if err != nil {
+		return GeneratorProfile{}, fmt.Errorf("pps: %w", err)
+	}
+	duration, err := strconv.Atoi(values["duration"])
+	if err != nil {
+		return GeneratorProfile{}, fmt.Errorf("duration: %w", err)
+	}
+	if duration == 0 {
+		duration = 999999 // continuous, as StartTrafficGeneration runs it
+	}
+	return GeneratorProfile{
+		Name:     values["name"],
+		Target:   values["target"],
+		Port:     port,
+		Protocol: values["protocol"],
+		Pattern:  "constant",
+		PPS:      pps,
+		Duration: duration,
+	}, nil
+}
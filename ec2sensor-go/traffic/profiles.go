@@ -0,0 +1,217 @@
+package traffic
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ParamSpec describes one field a ProfileSpec collects before it can build
+// its command - the per-profile parameter schema renderTrafficStart uses to
+// decide which inputs to prompt for, instead of the fixed IP/port/protocol/
+// pps/duration set every profile used to share.
+type ParamSpec struct {
+	Name     string `yaml:"name"`
+	Label    string `yaml:"label"`
+	Kind     string `yaml:"kind,omitempty"` // "string" (default), "int", or "float"
+	Default  string `yaml:"default,omitempty"`
+	Required bool   `yaml:"required,omitempty"`
+}
+
+// ProfileSpec is a named traffic-generation pattern: a parameter schema plus
+// the shell command template that runs it on the sensor once those
+// parameters are filled in. CommandTemplate placeholders are "{{name}}",
+// substituted with each Param's collected value.
+type ProfileSpec struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	// Tools lists the apt packages ConfigureTrafficGenerator should install
+	// before this profile's command can run (e.g. "hping3", "tcpreplay").
+	Tools  []string    `yaml:"tools,omitempty"`
+	Params []ParamSpec `yaml:"params"`
+	// CommandTemplate is unused by the pcap-replay profile, which is run
+	// through ssh.Client.UploadPCAP/ReplayPCAP instead - see IsPCAPReplay.
+	CommandTemplate string `yaml:"command"`
+}
+
+// IsPCAPReplay reports whether p is the built-in pcap-replay profile,
+// which the caller must drive through UploadPCAP/ReplayPCAP rather than
+// Command, since it needs to push a local file to the sensor first.
+func (p ProfileSpec) IsPCAPReplay() bool {
+	return p.Name == "pcap-replay"
+}
+
+// Command fills in p.CommandTemplate with values, applying each Param's
+// Default for anything left blank and coercing by Kind, then returns the
+// shell command ready to hand to ssh.Client.RunProfileCommand.
+func (p ProfileSpec) Command(values map[string]string) (string, error) {
+	cmd := p.CommandTemplate
+	for _, param := range p.Params {
+		v := strings.TrimSpace(values[param.Name])
+		if v == "" {
+			v = param.Default
+		}
+		if v == "" && param.Required {
+			return "", fmt.Errorf("%s: %s is required", p.Name, param.Label)
+		}
+		if err := param.validate(v); err != nil {
+			return "", fmt.Errorf("%s: %w", p.Name, err)
+		}
+		cmd = strings.ReplaceAll(cmd, "{{"+param.Name+"}}", v)
+	}
+	return cmd, nil
+}
+
+// validate checks v against param's Kind, if it has a value to check at
+// all - an optional field left at its zero value is fine.
+func (param ParamSpec) validate(v string) error {
+	if v == "" {
+		return nil
+	}
+	switch param.Kind {
+	case "int":
+		if _, err := strconv.Atoi(v); err != nil {
+			return fmt.Errorf("%s must be an integer: %v", param.Label, err)
+		}
+	case "float":
+		if _, err := strconv.ParseFloat(v, 64); err != nil {
+			return fmt.Errorf("%s must be a number: %v", param.Label, err)
+		}
+	}
+	return nil
+}
+
+// BuiltinProfiles returns the traffic-generation patterns ec2sensor ships
+// out of the box. scan-tcp-syn and dns-amplification run via hping3,
+// http-slowloris and mixed-benign-web via curl-loader, and pcap-replay
+// uploads a capture and drives it with tcpreplay - see ProfileSpec.Tools
+// for what ConfigureTrafficGenerator needs installed for each.
+func BuiltinProfiles() []ProfileSpec {
+	return []ProfileSpec{
+		{
+			Name:        "scan-tcp-syn",
+			Description: "hping3 SYN scan across a port range",
+			Tools:       []string{"hping3"},
+			Params: []ParamSpec{
+				{Name: "target", Label: "Target IP", Required: true},
+				{Name: "ports", Label: "Port range", Default: "1-1024"},
+				{Name: "duration", Label: "Duration (seconds)", Kind: "int", Default: "30"},
+			},
+			CommandTemplate: "timeout {{duration}} hping3 --scan {{ports}} -S {{target}}",
+		},
+		{
+			Name:        "dns-amplification",
+			Description: "Spoofed DNS queries flooded at a victim via a resolver",
+			Tools:       []string{"hping3"},
+			Params: []ParamSpec{
+				{Name: "target", Label: "Spoofed (victim) IP", Required: true},
+				{Name: "dns_server", Label: "DNS server IP", Required: true},
+				{Name: "pps", Label: "Packets/sec", Kind: "int", Default: "500"},
+				{Name: "duration", Label: "Duration (seconds)", Kind: "int", Default: "30"},
+			},
+			CommandTemplate: "timeout {{duration}} hping3 --udp -p 53 --spoof {{target}} --flood -i u$(( 1000000 / {{pps}} )) {{dns_server}}",
+		},
+		{
+			Name:        "http-slowloris",
+			Description: "curl-loader holding many slow HTTP connections open",
+			Tools:       []string{"curl-loader"},
+			Params: []ParamSpec{
+				{Name: "url", Label: "Target URL", Required: true},
+				{Name: "connections", Label: "Connections", Kind: "int", Default: "200"},
+				{Name: "duration", Label: "Duration (seconds)", Kind: "int", Default: "60"},
+			},
+			CommandTemplate: `cat > /tmp/ec2sensor_slowloris.conf << 'CONF'
+BATCH_NAME=slowloris
+URL_BATCH_NAME=slowloris
+URL="{{url}}" CYCLES=-1
+CLIENTS_NUM_MIN={{connections}} CLIENTS_NUM_MAX={{connections}}
+CONF
+timeout {{duration}} curl-loader -f /tmp/ec2sensor_slowloris.conf`,
+		},
+		{
+			Name:        "mixed-benign-web",
+			Description: "Steady rate of ordinary-looking HTTP GETs",
+			Tools:       []string{"curl-loader"},
+			Params: []ParamSpec{
+				{Name: "url", Label: "Target URL", Required: true},
+				{Name: "rps", Label: "Requests/sec", Kind: "int", Default: "50"},
+				{Name: "duration", Label: "Duration (seconds)", Kind: "int", Default: "120"},
+			},
+			CommandTemplate: `timeout {{duration}} bash -c 'while true; do curl -s -o /dev/null "{{url}}"; sleep "$(echo "scale=3; 1/{{rps}}" | bc)"; done'`,
+		},
+		{
+			Name:        "pcap-replay",
+			Description: "Replay an uploaded capture with tcpreplay",
+			Tools:       []string{"tcpreplay"},
+			Params: []ParamSpec{
+				{Name: "pcap_path", Label: "Local .pcap path", Required: true},
+				{Name: "speed", Label: "Speed multiplier", Kind: "float", Default: "1.0"},
+				{Name: "loop", Label: "Loop count (0=forever)", Kind: "int", Default: "1"},
+			},
+		},
+	}
+}
+
+// ProfilesDir resolves the custom profile library location:
+// $XDG_CONFIG_HOME/ec2sensor/profiles, falling back to
+// ~/.config/ec2sensor/profiles - the same layout ScenariosDir uses.
+func ProfilesDir() string {
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		return filepath.Join(xdg, "ec2sensor", "profiles")
+	}
+	return filepath.Join(os.Getenv("HOME"), ".config", "ec2sensor", "profiles")
+}
+
+// LoadProfile reads and parses a custom ProfileSpec from a YAML file.
+func LoadProfile(path string) (*ProfileSpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read profile: %w", err)
+	}
+	var p ProfileSpec
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("parse profile: %w", err)
+	}
+	if p.Name == "" {
+		p.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	return &p, nil
+}
+
+// ListProfiles returns every available profile, built-in ones first, then
+// any custom *.yaml/*.yml/*.json files under ProfilesDir (a missing
+// directory just yields none, matching ListScenarios). A custom profile
+// that fails to parse is skipped rather than failing the whole list, so one
+// bad file doesn't block picking any of the others.
+func ListProfiles() ([]ProfileSpec, error) {
+	profiles := append([]ProfileSpec{}, BuiltinProfiles()...)
+
+	dir := ProfilesDir()
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return profiles, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read profiles dir: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		ext := filepath.Ext(entry.Name())
+		if ext != ".yaml" && ext != ".yml" && ext != ".json" {
+			continue
+		}
+		p, err := LoadProfile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		profiles = append(profiles, *p)
+	}
+	return profiles, nil
+}
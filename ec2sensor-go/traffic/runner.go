@@ -0,0 +1,191 @@
+package traffic
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/quanticsoul4772/ec2sensor-go/models"
+	"github.com/quanticsoul4772/ec2sensor-go/ssh"
+)
+
+// Runner executes a Scenario's stages sequentially over SSH against one
+// sensor.
+type Runner struct {
+	client *ssh.Client
+}
+
+// NewRunner builds a Runner that drives client.
+func NewRunner(client *ssh.Client) *Runner {
+	return &Runner{client: client}
+}
+
+// Report is the result of running a Scenario against one sensor.
+type Report struct {
+	Scenario string
+	Sensor   string
+	Passed   bool
+	Stages   []StageResult
+}
+
+// StageResult is one stage's outcome: its metrics snapshot, how long it
+// took, and which assertions (if any) failed. Err is set when the stage
+// itself couldn't run (traffic failed to start, or metrics collection
+// failed) - Failures is only populated once metrics were collected.
+type StageResult struct {
+	Stage    string
+	Passed   bool
+	Started  time.Time
+	Duration time.Duration
+	Metrics  *models.SensorMetrics
+	Failures []string
+	Err      error
+}
+
+// Run executes every stage of scenario against ip in order, stopping early
+// if ctx is cancelled. A stage that errors or fails its assertions does not
+// abort later stages, so the report covers as much of the scenario as
+// possible.
+func (r *Runner) Run(ctx context.Context, ip string, scenario *Scenario) *Report {
+	report := &Report{Scenario: scenario.Name, Sensor: ip, Passed: true}
+
+	for _, stage := range scenario.Stages {
+		if ctx.Err() != nil {
+			break
+		}
+		result := r.runStage(ctx, ip, stage)
+		report.Stages = append(report.Stages, result)
+		if !result.Passed {
+			report.Passed = false
+		}
+	}
+
+	return report
+}
+
+// runStage starts stage's traffic pattern, waits out its duration, then
+// polls metrics and checks them against stage.Assertions.
+func (r *Runner) runStage(ctx context.Context, ip string, stage Stage) StageResult {
+	result := StageResult{Stage: stage.Name, Started: time.Now()}
+	defer func() { result.Duration = time.Since(result.Started) }()
+
+	if err := r.client.StartProfile(ip, stageProfile{stage}); err != nil {
+		result.Err = fmt.Errorf("start traffic: %w", err)
+		return result
+	}
+	defer r.client.StopTrafficGeneration(ip)
+
+	timer := time.NewTimer(time.Duration(stage.Duration) * time.Second)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+		result.Err = ctx.Err()
+		return result
+	}
+
+	metrics, err := r.client.CollectMetrics(ip)
+	if err != nil {
+		result.Err = fmt.Errorf("collect metrics: %w", err)
+		return result
+	}
+	result.Metrics = metrics
+
+	result.Passed = true
+	for _, assertion := range stage.Assertions {
+		if ok, failure := evaluate(metrics, assertion); !ok {
+			result.Passed = false
+			result.Failures = append(result.Failures, failure)
+		}
+	}
+	return result
+}
+
+// stageProfile implements ssh.TrafficProfile directly rather than going
+// through ssh.ConstantRate/ssh.Ramp, since a stage also needs to thread an
+// optional Payload through to the generator - a field neither of those
+// profiles carries.
+type stageProfile struct {
+	stage Stage
+}
+
+func (p stageProfile) Descriptor() map[string]any {
+	d := map[string]any{
+		"target":   p.stage.Target,
+		"port":     p.stage.Port,
+		"protocol": p.stage.Protocol,
+		"duration": p.stage.Duration,
+	}
+	if p.stage.StartPPS == p.stage.EndPPS {
+		d["mode"] = "constant"
+		d["pps"] = p.stage.StartPPS
+	} else {
+		d["mode"] = "ramp"
+		d["start_pps"] = p.stage.StartPPS
+		d["end_pps"] = p.stage.EndPPS
+	}
+	if p.stage.Payload != "" {
+		d["payload"] = p.stage.Payload
+	}
+	return d
+}
+
+// evaluate checks assertion against metrics, returning a human-readable
+// failure description when it doesn't hold.
+func evaluate(metrics *models.SensorMetrics, assertion Assertion) (bool, string) {
+	value, ok := metricField(metrics, assertion.Field)
+	if !ok {
+		return false, fmt.Sprintf("%s: unknown metric field", assertion.Field)
+	}
+
+	var pass bool
+	switch assertion.Op {
+	case ">=":
+		pass = value >= assertion.Value
+	case ">":
+		pass = value > assertion.Value
+	case "<=":
+		pass = value <= assertion.Value
+	case "<":
+		pass = value < assertion.Value
+	case "==":
+		pass = value == assertion.Value
+	case "!=":
+		pass = value != assertion.Value
+	default:
+		return false, fmt.Sprintf("%s: unknown operator %q", assertion.Field, assertion.Op)
+	}
+	if !pass {
+		return false, fmt.Sprintf("%s %s %v: got %v", assertion.Field, assertion.Op, assertion.Value, value)
+	}
+	return true, ""
+}
+
+// metricField looks up one field of metrics by its SensorReport JSON wire
+// name, the vocabulary a scenario file's assertions are written against.
+func metricField(metrics *models.SensorMetrics, field string) (float64, bool) {
+	switch field {
+	case "cpu":
+		return float64(metrics.CPU), true
+	case "memory":
+		return float64(metrics.Memory), true
+	case "disk":
+		return float64(metrics.Disk), true
+	case "pods":
+		return float64(metrics.Pods), true
+	case "net_rx":
+		return float64(metrics.NetRX), true
+	case "net_tx":
+		return float64(metrics.NetTX), true
+	case "load_avg_1":
+		return metrics.LoadAvg1, true
+	case "load_avg_5":
+		return metrics.LoadAvg5, true
+	case "load_avg_15":
+		return metrics.LoadAvg15, true
+	case "tcp_established":
+		return float64(metrics.TCPEstablished), true
+	default:
+		return 0, false
+	}
+}
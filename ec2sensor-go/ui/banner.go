@@ -0,0 +1,148 @@
+package ui
+
+import (
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// MinBannerWidth is the narrowest terminal width the ASCII-art banner will
+// render at. Narrower terminals fall back to the plain RenderHeader box.
+const MinBannerWidth = 70
+
+// defaultBanner is the built-in "EC2SENSOR" block-letter banner, shipped so
+// the app looks the same out of the box before any user override is applied.
+var defaultBanner = []string{
+	` _____ ____ ____    ____ _____ _   _ ____   ___  ____  `,
+	`| ____/ ___|___ \  / ___| ____| \ | / ___| / _ \|  _ \ `,
+	`|  _|| |     __) | \___ \  _| |  \| \___ \| | | | |_) |`,
+	`| |__| |___ / __/   ___) | |___| |\  |___) | |_| |  _ < `,
+	`|_____\____|_____| |____/|_____|_| \_|____/ \___/|_| \_\`,
+}
+
+// LoadBanner returns the lines of the ASCII-art banner to render. overridePath
+// (typically from config.toml's [ui] banner_file, or EC2SENSOR_BANNER_FILE)
+// takes precedence when set and readable; otherwise the built-in default
+// banner is used.
+func LoadBanner(overridePath string) []string {
+	if overridePath == "" {
+		overridePath = os.Getenv("EC2SENSOR_BANNER_FILE")
+	}
+	if overridePath != "" {
+		data, err := os.ReadFile(overridePath)
+		if err == nil {
+			lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+			if len(lines) > 0 {
+				return lines
+			}
+		}
+	}
+	return defaultBanner
+}
+
+// RenderBanner renders a multi-line ASCII-art banner, applying a per-row
+// foreground color from palette. When palette has fewer colors than the
+// banner has rows, intermediate rows interpolate between the two nearest
+// palette colors so the gradient still spans the whole banner. Every glyph
+// in a row, block character or not, gets that row's bold foreground style;
+// no background style is applied.
+func RenderBanner(s Styles, lines []string, palette []lipgloss.Color) string {
+	if len(lines) == 0 {
+		return ""
+	}
+	if len(palette) == 0 {
+		palette = []lipgloss.Color{s.Theme.Primary, s.Theme.Accent}
+	}
+
+	var b strings.Builder
+	for i, line := range lines {
+		color := bannerRowColor(palette, i, len(lines))
+		style := lipgloss.NewStyle().Foreground(color).Bold(true)
+		b.WriteString(style.Render(line))
+		if i < len(lines)-1 {
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// bannerRowColor returns the interpolated palette color for row i of total
+// rows, walking the palette as a sequence of gradient stops.
+func bannerRowColor(palette []lipgloss.Color, row, total int) lipgloss.Color {
+	if len(palette) == 1 || total <= 1 {
+		return palette[0]
+	}
+
+	// Position of this row along the [0, len(palette)-1] gradient.
+	t := float64(row) / float64(total-1) * float64(len(palette)-1)
+	lo := int(t)
+	if lo >= len(palette)-1 {
+		return palette[len(palette)-1]
+	}
+	frac := t - float64(lo)
+	return lerpColor(palette[lo], palette[lo+1], frac)
+}
+
+// lerpColor linearly interpolates between two hex lipgloss colors.
+func lerpColor(a, b lipgloss.Color, t float64) lipgloss.Color {
+	ar, ag, ab := hexToRGB(string(a))
+	br, bg, bb := hexToRGB(string(b))
+	r := lerp(ar, br, t)
+	g := lerp(ag, bg, t)
+	bl := lerp(ab, bb, t)
+	return lipgloss.Color(rgbToHex(r, g, bl))
+}
+
+func lerp(a, b uint8, t float64) uint8 {
+	return uint8(float64(a) + (float64(b)-float64(a))*t)
+}
+
+// hexToRGB parses a "#rrggbb" color. Non-hex values (e.g. ANSI numeric codes
+// used by the built-in themes) are treated as mid-gray so the interpolation
+// degrades gracefully instead of failing.
+func hexToRGB(hex string) (uint8, uint8, uint8) {
+	if len(hex) != 7 || hex[0] != '#' {
+		return 128, 128, 128
+	}
+	var r, g, bl uint8
+	parseByte := func(s string) uint8 {
+		var v uint8
+		for _, c := range s {
+			v *= 16
+			switch {
+			case c >= '0' && c <= '9':
+				v += uint8(c - '0')
+			case c >= 'a' && c <= 'f':
+				v += uint8(c-'a') + 10
+			case c >= 'A' && c <= 'F':
+				v += uint8(c-'A') + 10
+			}
+		}
+		return v
+	}
+	r = parseByte(hex[1:3])
+	g = parseByte(hex[3:5])
+	bl = parseByte(hex[5:7])
+	return r, g, bl
+}
+
+func rgbToHex(r, g, b uint8) string {
+	const hexDigits = "0123456789abcdef"
+	buf := []byte{'#', 0, 0, 0, 0, 0, 0}
+	buf[1], buf[2] = hexDigits[r>>4], hexDigits[r&0xf]
+	buf[3], buf[4] = hexDigits[g>>4], hexDigits[g&0xf]
+	buf[5], buf[6] = hexDigits[b>>4], hexDigits[b&0xf]
+	return string(buf)
+}
+
+// RenderMainHeader renders the main-screen header: the ASCII-art banner when
+// the terminal is at least MinBannerWidth columns wide, otherwise the plain
+// bordered RenderHeader.
+func RenderMainHeader(s Styles, width int, title, subtitle, bannerFile string) string {
+	if width < MinBannerWidth {
+		return RenderHeader(s, title, subtitle)
+	}
+	banner := LoadBanner(bannerFile)
+	return RenderBanner(s, banner, []lipgloss.Color{s.Theme.Primary, s.Theme.Secondary, s.Theme.Accent})
+}
@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// Capabilities describes what the attached terminal can actually render,
+// detected once at startup and threaded through NewStyles and the box-drawing
+// helpers so the UI degrades gracefully instead of printing garbage.
+type Capabilities struct {
+	ColorProfile   termenv.Profile
+	DarkBackground bool
+	Unicode        bool
+}
+
+// DetectCapabilities probes the terminal attached to stdout. EC2SENSOR_FORCE_COLOR
+// overrides the detected color profile ("true", "256", "16", "mono"/"ascii"/"0"),
+// and NO_COLOR (https://no-color.org) forces mono regardless of what the
+// terminal reports.
+func DetectCapabilities() Capabilities {
+	output := termenv.NewOutput(os.Stdout)
+	profile := output.Profile
+
+	if _, noColor := os.LookupEnv("NO_COLOR"); noColor {
+		profile = termenv.Ascii
+	} else if forced := os.Getenv("EC2SENSOR_FORCE_COLOR"); forced != "" {
+		switch strings.ToLower(forced) {
+		case "true", "truecolor", "24bit":
+			profile = termenv.TrueColor
+		case "256":
+			profile = termenv.ANSI256
+		case "16", "ansi":
+			profile = termenv.ANSI
+		case "mono", "ascii", "0", "none":
+			profile = termenv.Ascii
+		}
+	}
+
+	return Capabilities{
+		ColorProfile:   profile,
+		DarkBackground: output.HasDarkBackground(),
+		Unicode:        unicodeSupported(),
+	}
+}
+
+// unicodeSupported returns false when the locale clearly doesn't advertise a
+// UTF-8 charset, which is the signal most terminals give for box-drawing and
+// icon glyphs being unsafe to print.
+func unicodeSupported() bool {
+	for _, key := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if v := os.Getenv(key); v != "" {
+			return strings.Contains(strings.ToUpper(v), "UTF-8") || strings.Contains(strings.ToUpper(v), "UTF8")
+		}
+	}
+	return false
+}
+
+// AutoTheme picks DarkTheme, LightTheme, or MinimalTheme based on detected
+// capabilities: a mono/ascii terminal always gets MinimalTheme, otherwise the
+// choice follows the terminal's reported background luminance.
+func (c Capabilities) AutoTheme() Theme {
+	if c.ColorProfile <= termenv.Ascii {
+		return MinimalTheme
+	}
+	if c.DarkBackground {
+		return DarkTheme
+	}
+	return LightTheme
+}
+
+// BoxGlyphs holds the characters used to draw box borders. NewGlyphs falls
+// back to plain ASCII when the terminal doesn't support Unicode.
+type BoxGlyphs struct {
+	TL, TR, BL, BR, H, V string
+}
+
+// NewGlyphs returns the Unicode box-drawing set, or an ASCII fallback when
+// caps.Unicode is false.
+func NewGlyphs(caps Capabilities) BoxGlyphs {
+	if !caps.Unicode {
+		return BoxGlyphs{TL: "+", TR: "+", BL: "+", BR: "+", H: "-", V: "|"}
+	}
+	return BoxGlyphs{TL: BoxTL, TR: BoxTR, BL: BoxBL, BR: BoxBR, H: BoxH, V: BoxV}
+}
+
+// adaptiveFrom builds a lipgloss.AdaptiveColor from a theme's dark and light
+// variants of the same semantic color, used instead of a fixed 256-color code
+// so the rendered color stays legible regardless of terminal background.
+func adaptiveFrom(dark, light lipgloss.Color) lipgloss.AdaptiveColor {
+	return lipgloss.AdaptiveColor{Dark: string(dark), Light: string(light)}
+}
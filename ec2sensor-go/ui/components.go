@@ -193,12 +193,13 @@ func RenderAlertBox(s Styles, alertType string, title string, message string, de
 		maxWidth = 70
 	}
 	
-	// Build the box
-	horizontalBorder := strings.Repeat(BoxH, maxWidth+2)
+	// Build the box, downgrading to ASCII glyphs on terminals without Unicode support
+	g := s.Glyphs
+	horizontalBorder := strings.Repeat(g.H, maxWidth+2)
 	
 	// Top border
 	b.WriteString("  ")
-	b.WriteString(borderStyle.Render(BoxTL + horizontalBorder + BoxTR))
+	b.WriteString(borderStyle.Render(g.TL + horizontalBorder + g.TR))
 	b.WriteString("\n")
 	
 	// Title line with icon
@@ -208,16 +209,16 @@ func RenderAlertBox(s Styles, alertType string, title string, message string, de
 		padding = 0
 	}
 	b.WriteString("  ")
-	b.WriteString(borderStyle.Render(BoxV))
+	b.WriteString(borderStyle.Render(g.V))
 	b.WriteString(" ")
 	b.WriteString(titleStyle.Render(titleLine))
 	b.WriteString(strings.Repeat(" ", padding))
-	b.WriteString(borderStyle.Render(BoxV))
+	b.WriteString(borderStyle.Render(g.V))
 	b.WriteString("\n")
 	
 	// Separator
 	b.WriteString("  ")
-	b.WriteString(borderStyle.Render(BoxV + strings.Repeat(BoxH, maxWidth+2) + BoxV))
+	b.WriteString(borderStyle.Render(g.V + strings.Repeat(g.H, maxWidth+2) + g.V))
 	b.WriteString("\n")
 	
 	// Message line
@@ -227,11 +228,11 @@ func RenderAlertBox(s Styles, alertType string, title string, message string, de
 			msgPadding = 0
 		}
 		b.WriteString("  ")
-		b.WriteString(borderStyle.Render(BoxV))
+		b.WriteString(borderStyle.Render(g.V))
 		b.WriteString(" ")
 		b.WriteString(messageStyle.Render(message))
 		b.WriteString(strings.Repeat(" ", msgPadding))
-		b.WriteString(borderStyle.Render(BoxV))
+		b.WriteString(borderStyle.Render(g.V))
 		b.WriteString("\n")
 	}
 	
@@ -242,17 +243,17 @@ func RenderAlertBox(s Styles, alertType string, title string, message string, de
 			detailPadding = 0
 		}
 		b.WriteString("  ")
-		b.WriteString(borderStyle.Render(BoxV))
+		b.WriteString(borderStyle.Render(g.V))
 		b.WriteString("   ")
 		b.WriteString(s.Help.Render(detail))
 		b.WriteString(strings.Repeat(" ", detailPadding))
-		b.WriteString(borderStyle.Render(BoxV))
+		b.WriteString(borderStyle.Render(g.V))
 		b.WriteString("\n")
 	}
 	
 	// Bottom border
 	b.WriteString("  ")
-	b.WriteString(borderStyle.Render(BoxBL + horizontalBorder + BoxBR))
+	b.WriteString(borderStyle.Render(g.BL + horizontalBorder + g.BR))
 	
 	return b.String()
 }
@@ -300,29 +301,26 @@ type Shortcut struct {
 	Label string
 }
 
-// MainShortcuts returns shortcuts for main view
-func MainShortcuts() []Shortcut {
-	return []Shortcut{
-		{"r", "efresh"},
-		{"n", "ew"},
-		{"m", "ulti-select"},
-		{"t", "heme"},
-		{"q", "uit"},
-		{"?", "help"},
-	}
+// MainShortcuts returns the current main-view shortcuts, reflecting km's
+// bindings rather than the compiled-in defaults.
+func MainShortcuts(km *Keymap) []Shortcut {
+	return km.Shortcuts("main")
 }
 
-// OperationsShortcuts returns shortcuts for operations view
-func OperationsShortcuts() []Shortcut {
-	return []Shortcut{
-		{"c", "onnect"},
-		{"f", "eatures"},
-		{"u", "pgrade"},
-		{"d", "elete"},
-		{"h", "ealth"},
-		{"b", "ack"},
-		{"q", "uit"},
+// OperationsShortcuts returns the current operations-view shortcuts,
+// reflecting km's bindings rather than the compiled-in defaults. "Enable
+// features" stays on its fixed "f" key: it isn't part of the rebindable
+// action set.
+func OperationsShortcuts(km *Keymap) []Shortcut {
+	shortcuts := km.Shortcuts("operations")
+	out := make([]Shortcut, 0, len(shortcuts)+1)
+	for _, sc := range shortcuts {
+		out = append(out, sc)
+		if sc.Label == "onnect" {
+			out = append(out, Shortcut{"f", "eatures"})
+		}
 	}
+	return out
 }
 
 // RenderStatusBar renders the bottom status bar
@@ -511,8 +509,23 @@ func renderPaddedHealthValue(s Styles, value int, width int) string {
 	return s.HealthCritical.Render(valStr)
 }
 
-// RenderHelp renders the help screen
-func RenderHelp(s Styles, context string) string {
+// helpLines describes what each action does, for the help screen.
+var helpLines = map[Action]string{
+	ActionRefresh:     "Refresh sensor list",
+	ActionNew:         "Deploy new sensor",
+	ActionMultiSelect: "Multi-select mode",
+	ActionCycleTheme:  "Cycle color theme",
+	ActionSSHConnect:  "Connect via SSH",
+	ActionUpgrade:     "Upgrade sensor",
+	ActionDelete:      "Delete sensor",
+	ActionHealth:      "Health dashboard",
+	ActionBack:        "Back to list",
+	ActionQuit:        "Quit application",
+}
+
+// RenderHelp renders the help screen, showing km's current bindings rather
+// than the compiled-in defaults.
+func RenderHelp(s Styles, context string, km *Keymap) string {
 	var b strings.Builder
 
 	b.WriteString(RenderHeader(s, "KEYBOARD SHORTCUTS", "Help"))
@@ -524,27 +537,30 @@ func RenderHelp(s Styles, context string) string {
 	b.WriteString(fmt.Sprintf("  %s   Navigate up/down\n", s.MenuShortcut.Render("j/k")))
 	b.WriteString(fmt.Sprintf("  %s Navigate up/down\n", s.MenuShortcut.Render("↑/↓")))
 	b.WriteString(fmt.Sprintf("  %s   Confirm selection\n", s.MenuShortcut.Render("Enter")))
-	b.WriteString(fmt.Sprintf("  %s       Show this help\n", s.MenuShortcut.Render("?")))
+	b.WriteString(fmt.Sprintf("  %s       Show this help\n", s.MenuShortcut.Render(km.KeyFor(ActionHelp))))
 	b.WriteString("\n")
 
 	switch context {
 	case "main":
 		b.WriteString(RenderSection(s, "Main Menu Shortcuts"))
 		b.WriteString("\n")
-		b.WriteString(fmt.Sprintf("  %s       Refresh sensor list\n", s.MenuShortcut.Render("r")))
-		b.WriteString(fmt.Sprintf("  %s       Deploy new sensor\n", s.MenuShortcut.Render("n")))
-		b.WriteString(fmt.Sprintf("  %s       Multi-select mode\n", s.MenuShortcut.Render("m")))
-		b.WriteString(fmt.Sprintf("  %s       Cycle color theme\n", s.MenuShortcut.Render("t")))
-		b.WriteString(fmt.Sprintf("  %s       Quit application\n", s.MenuShortcut.Render("q")))
+		for _, action := range contextActions["main"] {
+			if action == ActionHelp {
+				continue
+			}
+			key := fmt.Sprintf("%-7s", km.KeyFor(action))
+			b.WriteString(fmt.Sprintf("  %s %s\n", s.MenuShortcut.Render(key), helpLines[action]))
+		}
 	case "operations":
 		b.WriteString(RenderSection(s, "Sensor Operations Shortcuts"))
 		b.WriteString("\n")
-		b.WriteString(fmt.Sprintf("  %s       Connect via SSH\n", s.MenuShortcut.Render("c")))
-		b.WriteString(fmt.Sprintf("  %s       Enable features\n", s.MenuShortcut.Render("f")))
-		b.WriteString(fmt.Sprintf("  %s       Upgrade sensor\n", s.MenuShortcut.Render("u")))
-		b.WriteString(fmt.Sprintf("  %s       Delete sensor\n", s.MenuShortcut.Render("d")))
-		b.WriteString(fmt.Sprintf("  %s       Health dashboard\n", s.MenuShortcut.Render("h")))
-		b.WriteString(fmt.Sprintf("  %s       Back to list\n", s.MenuShortcut.Render("b")))
+		for _, action := range contextActions["operations"] {
+			key := fmt.Sprintf("%-7s", km.KeyFor(action))
+			b.WriteString(fmt.Sprintf("  %s %s\n", s.MenuShortcut.Render(key), helpLines[action]))
+			if action == ActionSSHConnect {
+				b.WriteString(fmt.Sprintf("  %s %s\n", s.MenuShortcut.Render(fmt.Sprintf("%-7s", "f")), "Enable features"))
+			}
+		}
 	}
 
 	b.WriteString("\n")
@@ -0,0 +1,179 @@
+package ui
+
+import "fmt"
+
+// Action is a named operation the Bubble Tea update loop dispatches on,
+// decoupled from any particular key so the keymap can rebind it freely.
+type Action string
+
+const (
+	ActionRefresh     Action = "refresh"
+	ActionNew         Action = "new"
+	ActionMultiSelect Action = "multi_select"
+	ActionCycleTheme  Action = "cycle_theme"
+	ActionSSHConnect  Action = "ssh_connect"
+	ActionUpgrade     Action = "upgrade"
+	ActionDelete      Action = "delete"
+	ActionHealth      Action = "health"
+	ActionBack        Action = "back"
+	ActionQuit        Action = "quit"
+	ActionHelp        Action = "help"
+)
+
+// actionLabels gives the short help-footer label shown after the bound key,
+// e.g. the "efresh" in "[r]efresh".
+var actionLabels = map[Action]string{
+	ActionRefresh:     "efresh",
+	ActionNew:         "ew",
+	ActionMultiSelect: "ulti-select",
+	ActionCycleTheme:  "heme",
+	ActionSSHConnect:  "onnect",
+	ActionUpgrade:     "pgrade",
+	ActionDelete:      "elete",
+	ActionHealth:      "ealth",
+	ActionBack:        "ack",
+	ActionQuit:        "uit",
+	ActionHelp:        "help",
+}
+
+// contextActions lists, per context, which actions apply and in what order
+// they're shown in the shortcut footer / help screen.
+var contextActions = map[string][]Action{
+	"main":       {ActionRefresh, ActionNew, ActionMultiSelect, ActionCycleTheme, ActionQuit, ActionHelp},
+	"operations": {ActionSSHConnect, ActionUpgrade, ActionDelete, ActionHealth, ActionBack, ActionQuit},
+}
+
+// defaultBindings is the compiled-in keymap, matching the application's
+// original hard-coded shortcuts. Keys may be a single key ("r", "ctrl+r",
+// "f5") or a space-separated sequence ("g g").
+func defaultBindings() map[Action]string {
+	return map[Action]string{
+		ActionRefresh:     "r",
+		ActionNew:         "n",
+		ActionMultiSelect: "m",
+		ActionCycleTheme:  "t",
+		ActionSSHConnect:  "c",
+		ActionUpgrade:     "u",
+		ActionDelete:      "d",
+		ActionHealth:      "h",
+		ActionBack:        "b",
+		ActionQuit:        "q",
+		ActionHelp:        "?",
+	}
+}
+
+// Keymap holds the action -> key bindings consulted by the update loop
+// instead of hard-coded key letters, along with the reverse per-context
+// lookup used to dispatch an incoming keypress.
+type Keymap struct {
+	keys      map[Action]string
+	byContext map[string]map[string]Action
+}
+
+// DefaultKeymap returns the compiled-in keymap.
+func DefaultKeymap() *Keymap {
+	km := &Keymap{keys: defaultBindings()}
+	km.reindex()
+	return km
+}
+
+// LoadKeymap builds a Keymap from the compiled-in defaults overridden by
+// entries (config.toml's [keys] section, action name -> key). Returns an
+// error if the resulting keymap binds the same key to two actions within
+// the same context.
+func LoadKeymap(entries map[string]string) (*Keymap, error) {
+	km := &Keymap{keys: defaultBindings()}
+	for action, key := range entries {
+		if key == "" {
+			continue
+		}
+		km.keys[Action(action)] = key
+	}
+	km.reindex()
+	if err := km.validate(); err != nil {
+		return nil, err
+	}
+	return km, nil
+}
+
+// reindex rebuilds the per-context key -> action lookup from km.keys.
+func (km *Keymap) reindex() {
+	km.byContext = make(map[string]map[string]Action, len(contextActions))
+	for context, actions := range contextActions {
+		bound := make(map[string]Action, len(actions))
+		for _, action := range actions {
+			if key, ok := km.keys[action]; ok {
+				bound[key] = action
+			}
+		}
+		km.byContext[context] = bound
+	}
+}
+
+// validate rejects a keymap where, within a single context, the same key is
+// bound to more than one action.
+func (km *Keymap) validate() error {
+	for context, actions := range contextActions {
+		seen := make(map[string]Action, len(actions))
+		for _, action := range actions {
+			key, ok := km.keys[action]
+			if !ok {
+				continue
+			}
+			if other, exists := seen[key]; exists {
+				return fmt.Errorf("keymap conflict in context %q: key %q is bound to both %q and %q", context, key, other, action)
+			}
+			seen[key] = action
+		}
+	}
+	return nil
+}
+
+// Resolve returns the action bound to key in context, if any.
+func (km *Keymap) Resolve(context, key string) (Action, bool) {
+	action, ok := km.byContext[context][key]
+	return action, ok
+}
+
+// IsSequencePrefix reports whether key is the first key of some multi-key
+// sequence (e.g. "g") bound in context (e.g. "g g"), so the caller knows to
+// wait for the next keypress rather than treat it as unmatched.
+func (km *Keymap) IsSequencePrefix(context, key string) bool {
+	for _, action := range contextActions[context] {
+		bound, ok := km.keys[action]
+		if !ok {
+			continue
+		}
+		if len(bound) > len(key)+1 && bound[:len(key)] == key && bound[len(key)] == ' ' {
+			return true
+		}
+	}
+	return false
+}
+
+// KeyFor returns the key bound to action, or "" if unbound.
+func (km *Keymap) KeyFor(action Action) string {
+	return km.keys[action]
+}
+
+// DefaultKeyFor returns action's compiled-in default key, used to translate
+// a rebound key back to the literal the rest of the update loop still
+// switches on.
+func DefaultKeyFor(action Action) string {
+	return defaultBindings()[action]
+}
+
+// Shortcuts returns the current key bindings for context as Shortcuts, in a
+// fixed display order, reflecting whatever the keymap actually has bound
+// rather than the compiled-in defaults.
+func (km *Keymap) Shortcuts(context string) []Shortcut {
+	var out []Shortcut
+	for _, action := range contextActions[context] {
+		key, ok := km.keys[action]
+		if !ok {
+			continue
+		}
+		out = append(out, Shortcut{Key: key, Label: actionLabels[action]})
+	}
+	return out
+}
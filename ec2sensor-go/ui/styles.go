@@ -2,6 +2,7 @@ package ui
 
 import (
 	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 )
 
 // Theme represents a color theme for the UI
@@ -123,12 +124,31 @@ type Styles struct {
 	Help       lipgloss.Style
 	StatusBar  lipgloss.Style
 	Breadcrumb lipgloss.Style
+
+	// Glyphs holds the box-drawing characters to use for this terminal,
+	// downgraded to ASCII when the terminal doesn't support Unicode.
+	Glyphs BoxGlyphs
 }
 
-// NewStyles creates a new Styles instance with the given theme
-func NewStyles(theme Theme) Styles {
+// NewStyles creates a new Styles instance for the given theme, adapted to the
+// terminal capabilities in caps (color depth, background, Unicode support).
+func NewStyles(theme Theme, caps Capabilities) Styles {
+	// Prefer an adaptive accent color over a fixed 256-color code when the
+	// terminal profile supports it, so it stays legible on both light and
+	// dark backgrounds without the user having to pick a theme manually.
+	var accent lipgloss.TerminalColor = theme.Accent
+	if caps.ColorProfile >= termenv.ANSI256 {
+		accent = adaptiveFrom(DarkTheme.Accent, LightTheme.Accent)
+	}
+
+	borderStyle := lipgloss.RoundedBorder()
+	if !caps.Unicode {
+		borderStyle = lipgloss.NormalBorder()
+	}
+
 	return Styles{
-		Theme: theme,
+		Theme:  theme,
+		Glyphs: NewGlyphs(caps),
 
 		// Layout styles
 		App: lipgloss.NewStyle().
@@ -136,9 +156,9 @@ func NewStyles(theme Theme) Styles {
 
 		Header: lipgloss.NewStyle().
 			Bold(true).
-			Foreground(theme.Accent).
-			BorderStyle(lipgloss.RoundedBorder()).
-			BorderForeground(theme.Accent).
+			Foreground(accent).
+			BorderStyle(borderStyle).
+			BorderForeground(accent).
 			Padding(0, 2).
 			Width(60).
 			Align(lipgloss.Center),
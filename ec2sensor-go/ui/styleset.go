@@ -0,0 +1,317 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"gopkg.in/yaml.v3"
+)
+
+// StyleAttrs describes the renderable attributes for a single semantic style key.
+type StyleAttrs struct {
+	Foreground string `yaml:"foreground"`
+	Background string `yaml:"background"`
+	Bold       bool   `yaml:"bold"`
+	Italic     bool   `yaml:"italic"`
+	Underline  bool   `yaml:"underline"`
+	Reverse    bool   `yaml:"reverse"`
+}
+
+// Styleset is a named collection of semantic style key -> attribute mappings.
+// Keys may use a "*" wildcard segment (e.g. "alert_border.*") to set a default
+// that more specific keys (e.g. "alert_border.error") then override.
+type Styleset struct {
+	Name    string
+	Entries map[string]StyleAttrs
+}
+
+// styleKeys enumerates every semantic key a styleset entry is allowed to target.
+// Anything outside this set (after wildcard expansion) is rejected by Validate.
+var styleKeys = map[string]bool{
+	"table_header":         true,
+	"table_row":            true,
+	"table_row_alt":        true,
+	"table_row_selected":   true,
+	"table_border":         true,
+	"status_running":       true,
+	"status_pending":       true,
+	"status_error":         true,
+	"status_stopped":       true,
+	"health_good":          true,
+	"health_warning":       true,
+	"health_critical":      true,
+	"menu_item":            true,
+	"menu_item_active":     true,
+	"menu_shortcut":        true,
+	"breadcrumb":           true,
+	"section":              true,
+	"help":                 true,
+	"status_bar":           true,
+	"alert_border.error":   true,
+	"alert_border.warning": true,
+	"alert_border.success": true,
+	"alert_border.info":    true,
+}
+
+// defaultStyleset returns the built-in styleset matching the currently active theme.
+// It is always merged underneath any user-supplied styleset so that a partial
+// user file only needs to specify the keys it wants to override.
+func defaultStyleset(theme Theme) *Styleset {
+	hex := func(c lipgloss.Color) string { return string(c) }
+	return &Styleset{
+		Name: "builtin:" + theme.Name,
+		Entries: map[string]StyleAttrs{
+			"table_header":         {Foreground: hex(theme.Text), Bold: true},
+			"status_running":       {Foreground: hex(theme.Success)},
+			"status_pending":       {Foreground: hex(theme.Warning)},
+			"status_error":         {Foreground: hex(theme.Error)},
+			"status_stopped":       {Foreground: hex(theme.Warning)},
+			"health_good":          {Foreground: hex(theme.Success)},
+			"health_warning":       {Foreground: hex(theme.Warning)},
+			"health_critical":      {Foreground: hex(theme.Error)},
+			"menu_shortcut":        {Foreground: hex(theme.Info), Bold: true},
+			"breadcrumb":           {Foreground: hex(theme.Accent), Bold: true},
+			"section":              {Foreground: hex(theme.Accent), Bold: true},
+			"help":                 {Foreground: hex(theme.Subtle)},
+			"alert_border.error":   {Foreground: hex(theme.Error)},
+			"alert_border.warning": {Foreground: hex(theme.Warning)},
+			"alert_border.success": {Foreground: hex(theme.Success)},
+			"alert_border.info":    {Foreground: hex(theme.Info)},
+		},
+	}
+}
+
+// StylesetLoader loads and merges user stylesets with the built-in defaults.
+type StylesetLoader struct {
+	// Dir is the directory stylesets are read from, e.g. ~/.ec2sensor/stylesets.
+	Dir string
+}
+
+// NewStylesetLoader returns a loader rooted at the user's styleset directory.
+func NewStylesetLoader() *StylesetLoader {
+	home, _ := os.UserHomeDir()
+	return &StylesetLoader{Dir: filepath.Join(home, ".ec2sensor", "stylesets")}
+}
+
+// ListStylesets returns the names of stylesets available in the loader's
+// directory (without file extension), sorted alphabetically. Used by the
+// `t` cycle key to advance through user-defined stylesets after the three
+// built-in themes.
+func (l *StylesetLoader) ListStylesets() []string {
+	entries, err := os.ReadDir(l.Dir)
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		ext := filepath.Ext(e.Name())
+		if ext != ".yaml" && ext != ".yml" && ext != ".ini" {
+			continue
+		}
+		names = append(names, strings.TrimSuffix(e.Name(), ext))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Load reads the named styleset from disk and merges it on top of the
+// built-in defaults for theme. An unknown style key in the file is a hard
+// error so typos surface immediately rather than silently doing nothing.
+func (l *StylesetLoader) Load(name string, theme Theme) (*Styleset, error) {
+	base := defaultStyleset(theme)
+
+	path := filepath.Join(l.Dir, name+".yaml")
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		path = filepath.Join(l.Dir, name+".yml")
+		data, err = os.ReadFile(path)
+	}
+	if os.IsNotExist(err) {
+		path = filepath.Join(l.Dir, name+".ini")
+		data, err = os.ReadFile(path)
+		if err == nil {
+			user, perr := parseIniStyleset(name, data)
+			if perr != nil {
+				return nil, perr
+			}
+			if verr := user.Validate(); verr != nil {
+				return nil, verr
+			}
+			return mergeStylesets(base, user), nil
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load styleset %q: %w", name, err)
+	}
+
+	user := &Styleset{Name: name}
+	if err := yaml.Unmarshal(data, &user.Entries); err != nil {
+		return nil, fmt.Errorf("parse styleset %q: %w", name, err)
+	}
+	if err := user.Validate(); err != nil {
+		return nil, err
+	}
+
+	return mergeStylesets(base, user), nil
+}
+
+// Validate rejects any entry key that doesn't resolve to a known semantic
+// style key, either directly or via a "*" wildcard segment.
+func (ss *Styleset) Validate() error {
+	var unknown []string
+	for key := range ss.Entries {
+		if styleKeys[key] {
+			continue
+		}
+		if isWildcardKey(key) {
+			continue
+		}
+		unknown = append(unknown, key)
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("styleset %q: unknown style key(s): %s", ss.Name, strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// isWildcardKey reports whether key is a valid "prefix.*" wildcard that
+// expands to at least one concrete key in styleKeys.
+func isWildcardKey(key string) bool {
+	if !strings.HasSuffix(key, ".*") && key != "*" {
+		return false
+	}
+	prefix := strings.TrimSuffix(key, "*")
+	for k := range styleKeys {
+		if prefix == "" || strings.HasPrefix(k, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeStylesets starts from base, then applies the user's wildcards, then
+// the user's specific overrides, so a user's "*" replaces built-in entries
+// too and only the user's own specific keys take precedence over it.
+func mergeStylesets(base, user *Styleset) *Styleset {
+	merged := &Styleset{Name: user.Name, Entries: make(map[string]StyleAttrs, len(base.Entries))}
+
+	for k, v := range base.Entries {
+		merged.Entries[k] = v
+	}
+
+	// Apply user wildcards first, expanding them to every matching concrete key.
+	for key, attrs := range user.Entries {
+		if key == "*" {
+			for k := range styleKeys {
+				merged.Entries[k] = attrs
+			}
+		} else if strings.HasSuffix(key, ".*") {
+			prefix := strings.TrimSuffix(key, "*")
+			for k := range styleKeys {
+				if strings.HasPrefix(k, prefix) {
+					merged.Entries[k] = attrs
+				}
+			}
+		}
+	}
+
+	// Specific user keys always win last.
+	for key, attrs := range user.Entries {
+		if key == "*" || strings.HasSuffix(key, ".*") {
+			continue
+		}
+		merged.Entries[key] = attrs
+	}
+
+	return merged
+}
+
+// parseIniStyleset parses a minimal `key = value` per-line INI file where
+// value is a comma-separated list of "fg=<color>", "bg=<color>", "bold",
+// "italic", "underline", "reverse" tokens, e.g.:
+//
+//	table_header = fg=39,bold
+//	alert_border.error = fg=196,bg=234,underline
+func parseIniStyleset(name string, data []byte) (*Styleset, error) {
+	ss := &Styleset{Name: name, Entries: make(map[string]StyleAttrs)}
+	for lineNum, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("styleset %q: malformed line %d: %q", name, lineNum+1, line)
+		}
+		key := strings.TrimSpace(parts[0])
+		var attrs StyleAttrs
+		for _, tok := range strings.Split(parts[1], ",") {
+			tok = strings.TrimSpace(tok)
+			switch {
+			case strings.HasPrefix(tok, "fg="):
+				attrs.Foreground = strings.TrimPrefix(tok, "fg=")
+			case strings.HasPrefix(tok, "bg="):
+				attrs.Background = strings.TrimPrefix(tok, "bg=")
+			case tok == "bold":
+				attrs.Bold = true
+			case tok == "italic":
+				attrs.Italic = true
+			case tok == "underline":
+				attrs.Underline = true
+			case tok == "reverse":
+				attrs.Reverse = true
+			}
+		}
+		ss.Entries[key] = attrs
+	}
+	return ss, nil
+}
+
+// ApplyStyleset overlays the entries in ss onto s, returning a new Styles
+// value. Fields with no corresponding entry are left untouched.
+func ApplyStyleset(s Styles, ss *Styleset) Styles {
+	apply := func(base lipgloss.Style, key string) lipgloss.Style {
+		attrs, ok := ss.Entries[key]
+		if !ok {
+			return base
+		}
+		st := base
+		if attrs.Foreground != "" {
+			st = st.Foreground(lipgloss.Color(attrs.Foreground))
+		}
+		if attrs.Background != "" {
+			st = st.Background(lipgloss.Color(attrs.Background))
+		}
+		return st.Bold(attrs.Bold).Italic(attrs.Italic).Underline(attrs.Underline).Reverse(attrs.Reverse)
+	}
+
+	s.TableHeader = apply(s.TableHeader, "table_header")
+	s.TableRow = apply(s.TableRow, "table_row")
+	s.TableRowAlt = apply(s.TableRowAlt, "table_row_alt")
+	s.TableRowSelected = apply(s.TableRowSelected, "table_row_selected")
+	s.TableBorder = apply(s.TableBorder, "table_border")
+	s.StatusRunning = apply(s.StatusRunning, "status_running")
+	s.StatusPending = apply(s.StatusPending, "status_pending")
+	s.StatusError = apply(s.StatusError, "status_error")
+	s.StatusStopped = apply(s.StatusStopped, "status_stopped")
+	s.HealthGood = apply(s.HealthGood, "health_good")
+	s.HealthWarning = apply(s.HealthWarning, "health_warning")
+	s.HealthCritical = apply(s.HealthCritical, "health_critical")
+	s.MenuItem = apply(s.MenuItem, "menu_item")
+	s.MenuItemActive = apply(s.MenuItemActive, "menu_item_active")
+	s.MenuShortcut = apply(s.MenuShortcut, "menu_shortcut")
+	s.Breadcrumb = apply(s.Breadcrumb, "breadcrumb")
+	s.Section = apply(s.Section, "section")
+	s.Help = apply(s.Help, "help")
+	s.StatusBar = apply(s.StatusBar, "status_bar")
+
+	return s
+}